@@ -5,11 +5,24 @@ package gormplus
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
 // Common errors returned by base model operations.
@@ -26,301 +39,3976 @@ var (
 	// ErrDangerous is returned when attempting potentially dangerous operations
 	// like deleting without conditions.
 	ErrDangerous = errors.New("dangerous operation is prohibited")
+
+	// ErrNoPrimaryKey is returned when an operation requires a primary key
+	// but the model does not define one.
+	ErrNoPrimaryKey = errors.New("model has no primary key")
+
+	// ErrInvalidColumn is returned when a column is unknown to the model
+	// or not permitted for the requested operation.
+	ErrInvalidColumn = errors.New("invalid column")
+
+	// ErrVersionConflict is returned by optimistic updates when the record's version
+	// changed since it was read.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrInvalidScope is returned when a scope is constructed with invalid arguments.
+	ErrInvalidScope = errors.New("invalid scope")
+
+	// ErrSchemaDrift is returned when the database table does not match the model.
+	ErrSchemaDrift = errors.New("schema drift")
+
+	// ErrUnsupported is returned when an operation is not supported by the database dialect,
+	// or by the model, such as soft-delete operations on a model without a soft-delete field.
+	ErrUnsupported = errors.New("not supported by the database dialect")
+
+	// ErrForeignKeyViolation is returned when a write violates a foreign key constraint,
+	// such as deleting a record that other records still reference. The driver error
+	// remains available through errors.As.
+	ErrForeignKeyViolation = errors.New("foreign key violation")
 )
 
 // BaseModel is a generic base model that provides common database operations
 // for entities of type T. It wraps a GORM database instance and provides
 // type-safe methods for CRUD operations, querying, and transaction handling.
 type BaseModel[T any] struct {
-	db *gorm.DB
+	db        *gorm.DB
+	schema    *schema.Schema
+	deletedAt *schema.Field // soft-delete field, nil if the model has none
+	opts      options
+	hooks     hooks[T]
+
+	nextReplica atomic.Uint64 // round-robin position over opts.replicas
+}
+
+// Option configures optional behavior of a BaseModel at construction time.
+type Option func(*options)
+
+// options holds the configuration applied by Option functions.
+type options struct {
+	encrypted []encryptedColumn
+	table     string
+	replicas  []*gorm.DB
+	observer  Observer
+	slow      time.Duration
+	onSlow    func(ctx context.Context, op string, d time.Duration)
+	logger    logger.Interface
+	prepare   bool
+	pageSize  int // default page size, 0 for 20
+	maxPage   int // maximum page size, 0 for 1000
+}
+
+// WithTableName makes the base model issue all queries against table instead of the table
+// derived from T, e.g. to back both "users" and "users_archive" with the same struct.
+func WithTableName(table string) Option {
+	return func(o *options) {
+		o.table = table
+	}
+}
+
+// WithReplicas routes reads to the given read replicas, chosen round-robin, while writes and
+// transactions stay on the primary passed to the constructor. Routed reads are those built
+// on the base model's query scopes: First, Last, List, Page, Count, Exists, aggregates and
+// similar. Reads made inside a transaction, passed explicitly or carried by the context,
+// always run on the primary so they see its uncommitted writes.
+func WithReplicas(dbs ...*gorm.DB) Option {
+	return func(o *options) {
+		o.replicas = append(o.replicas, dbs...)
+	}
+}
+
+// WithLogger makes the base model log through l instead of the logger the DB was opened
+// with, e.g. to enable SQL logging for one problematic repository only. It applies to the
+// base model's own queries and transactions, including those on replicas; transactions
+// passed in explicitly keep their own logger.
+func WithLogger(l logger.Interface) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WithPreparedStmt makes the base model run its queries in a session with PrepareStmt, so
+// GORM prepares each distinct SQL statement once and reuses it across calls, saving the
+// parsing cost on hot paths. Transactions opened by the base model (Transact and friends)
+// reuse the cached statements too; transactions passed in explicitly run unprepared unless
+// their own DB prepares statements.
+// Prepared statements are cached per underlying DB, shared with any other PrepareStmt
+// session on it, and held open until evicted or the DB is closed. Queries whose SQL varies
+// with their input, such as IN lists of varying length, each add an entry, so bound the
+// cache with gorm.Config's PrepareStmtMaxSize and PrepareStmtTTL when they are common.
+func WithPreparedStmt() Option {
+	return func(o *options) {
+		o.prepare = true
+	}
+}
+
+// WithDefaultPageSize sets the page size Page and the other paginated reads use when called
+// with pageSize <= 0, instead of 20. Values <= 0 keep the default.
+func WithDefaultPageSize(n int) Option {
+	return func(o *options) {
+		o.pageSize = n
+	}
+}
+
+// WithMaxPageSize sets the largest page size Page and the other paginated reads return,
+// instead of 1000; larger requests are capped. Values <= 0 keep the default.
+func WithMaxPageSize(n int) Option {
+	return func(o *options) {
+		o.maxPage = n
+	}
+}
+
+// Observer receives a notification when a base model operation completes, for metrics and
+// tracing. op is the method name, such as "Create" or "List", table the base model's table,
+// d the duration of the call and err its result. Each call is reported once under its own
+// name, even where one method is a variant of another (Transact and TransactWithOptions,
+// Update and UpdateAffected). Operations composed of others, such as SaveAll of Create and
+// Update, report those as well, like nested spans.
+// ObserveOp is called synchronously and must be safe for concurrent use.
+type Observer interface {
+	ObserveOp(ctx context.Context, op, table string, d time.Duration, err error)
+}
+
+// WithObserver reports every base model operation to obs.
+func WithObserver(obs Observer) Option {
+	return func(o *options) {
+		o.observer = obs
+	}
+}
+
+// WithSlowQueryThreshold calls fn for every operation that takes longer than d, as a
+// lightweight alternative to WithObserver for alerting. op identifies the operation by table
+// and method name, e.g. "users.List".
+func WithSlowQueryThreshold(d time.Duration, fn func(ctx context.Context, op string, d time.Duration)) Option {
+	return func(o *options) {
+		o.slow = d
+		o.onSlow = fn
+	}
+}
+
+// observe reports op, started at start, to the observer and the slow query callback if
+// configured. It is deferred at the top of each operation with a pointer to its named
+// error result.
+func (r *BaseModel[T]) observe(ctx context.Context, op string, start time.Time, err *error) {
+	if r.opts.observer == nil && r.opts.onSlow == nil {
+		return
+	}
+	d := time.Since(start)
+	if r.opts.observer != nil {
+		r.opts.observer.ObserveOp(ctx, op, r.TableName(), d, *err)
+	}
+	if r.opts.onSlow != nil && d > r.opts.slow {
+		r.opts.onSlow(ctx, r.TableName()+"."+op, d)
+	}
+}
+
+// encryptedColumn describes a column that is encrypted at rest.
+type encryptedColumn struct {
+	column string
+	field  *schema.Field
+	enc    func([]byte) ([]byte, error)
+	dec    func([]byte) ([]byte, error)
+}
+
+// WithEncryptColumn transparently encrypts column with enc when entities are written
+// (Create, Update, UpdateWithVersion, BatchInsert, InsertRows) and decrypts it with dec when
+// they are read as T (First, List, Page, Union, RawQuery and the locking reads). The column
+// must be a string or []byte field; ciphertext for string fields is stored base64-encoded.
+// Empty values are stored as-is.
+// Column updates through UpdateColumn/UpdateColumns and Exec are not encrypted, and Pluck,
+// Scan and ScanInto return the stored ciphertext, since their results are not entities.
+func WithEncryptColumn(column string, enc, dec func([]byte) ([]byte, error)) Option {
+	return func(o *options) {
+		o.encrypted = append(o.encrypted, encryptedColumn{column: column, enc: enc, dec: dec})
+	}
 }
 
 // Scope represents a function that can modify a GORM database query.
 // Scopes can be chained together to build complex queries in a composable way.
 type Scope func(*gorm.DB) *gorm.DB
 
+// AggregateFunc is a SQL aggregate function supported by AggregateNull.
+type AggregateFunc string
+
+// Supported aggregate functions.
+const (
+	AggregateSum AggregateFunc = "SUM"
+	AggregateAvg AggregateFunc = "AVG"
+	AggregateMin AggregateFunc = "MIN"
+	AggregateMax AggregateFunc = "MAX"
+)
+
+// WriteOpKind identifies the kind of write performed by a WriteOp.
+type WriteOpKind int
+
+// Supported write operation kinds.
+const (
+	WriteCreate WriteOpKind = iota + 1
+	WriteUpdate
+	WriteDelete
+)
+
+// WriteOp is a single write in a batch executed by ExecuteBatch.
+// Create and update operations use Entity; delete operations use Scopes.
+type WriteOp[T any] struct {
+	Kind   WriteOpKind
+	Entity *T
+	Scopes []Scope
+}
+
+// CreateOp returns a WriteOp that inserts ent.
+func CreateOp[T any](ent *T) WriteOp[T] {
+	return WriteOp[T]{Kind: WriteCreate, Entity: ent}
+}
+
+// UpdateOp returns a WriteOp that saves all fields of ent.
+func UpdateOp[T any](ent *T) WriteOp[T] {
+	return WriteOp[T]{Kind: WriteUpdate, Entity: ent}
+}
+
+// DeleteOp returns a WriteOp that deletes records matching scopes.
+// As with Delete, at least one scope is required.
+func DeleteOp[T any](scopes ...Scope) WriteOp[T] {
+	return WriteOp[T]{Kind: WriteDelete, Scopes: scopes}
+}
+
+// FilterValidator restricts API-driven filtering and sorting to an allowlist of model columns.
+// Columns must both exist on the model and be allowed, so sensitive columns can be
+// hidden from clients even though they are part of the schema.
+type FilterValidator[T any] struct {
+	schema  *schema.Schema
+	allowed map[string]struct{}
+}
+
 // PageResult represents the result of a paginated query.
 type PageResult[T any] struct {
-	Items    []T   `json:"items"`     // The items in the current page
-	Total    int64 `json:"total"`     // Total number of items across all pages
-	Page     int   `json:"page"`      // Current page number (1-based)
-	PageSize int   `json:"page_size"` // Number of items per page
-	HasNext  bool  `json:"has_next"`  // Whether there are more pages available
+	Items      []T   `json:"items"`       // The items in the current page
+	Total      int64 `json:"total"`       // Total number of items across all pages
+	Page       int   `json:"page"`        // Current page number (1-based)
+	PageSize   int   `json:"page_size"`   // Number of items per page
+	TotalPages int   `json:"total_pages"` // Total number of pages
+	HasNext    bool  `json:"has_next"`    // Whether there are more pages available
+	HasPrev    bool  `json:"has_prev"`    // Whether there are previous pages available
 }
 
 // NewBaseModel creates a new generic base model instance for type T.
-// It validates that T is a struct type.
-// Returns an error if T is not a valid struct type.
-func NewBaseModel[T any](db *gorm.DB) (*BaseModel[T], error) {
+// It validates that T is a struct type, parses its GORM schema and applies the options.
+// Returns an error if T is not a valid struct type, its schema cannot be parsed,
+// or an option refers to an unsuitable column.
+func NewBaseModel[T any](db *gorm.DB, opts ...Option) (*BaseModel[T], error) {
 	var zero T
 
 	t := reflect.TypeOf(zero)
-	if t.Kind() == reflect.Pointer {
+	if t == nil || t.Kind() == reflect.Pointer {
 		return nil, ErrInvalidType
 	}
 	if t.Kind() != reflect.Struct {
 		return nil, ErrInvalidType
 	}
 
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	for i, c := range o.encrypted {
+		f := stmt.Schema.LookUpField(c.column)
+		if f == nil || f.DBName == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidColumn, c.column)
+		}
+		if f.FieldType.Kind() != reflect.String && f.FieldType != reflect.TypeOf([]byte(nil)) {
+			return nil, fmt.Errorf("%w: encrypted column %s must be a string or []byte", ErrInvalidColumn, c.column)
+		}
+		o.encrypted[i].field = f
+	}
+	sess := &gorm.Session{Logger: o.logger, PrepareStmt: o.prepare}
+	if err := registerTimeoutRelease(db); err != nil {
+		return nil, err
+	}
+	for i, rep := range o.replicas {
+		if err := registerTimeoutRelease(rep); err != nil {
+			return nil, err
+		}
+		o.replicas[i] = rep.Session(sess)
+	}
+
 	return &BaseModel[T]{
-		db: db.Session(&gorm.Session{NewDB: false}),
+		db:        db.Session(sess),
+		schema:    stmt.Schema,
+		deletedAt: softDeleteField(stmt.Schema),
+		opts:      o,
 	}, nil
 }
 
-// Transact executes the provided function within a database transaction.
-// If the function returns an error, the transaction is rolled back.
-// Otherwise, the transaction is committed.
-func (r *BaseModel[T]) Transact(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error { return fn(ctx, tx) })
+// NewRepo is an alias for NewBaseModel for code written against the repository naming.
+// The returned type is BaseModel[T]; NewBaseModel is the canonical constructor.
+func NewRepo[T any](db *gorm.DB, opts ...Option) (*BaseModel[T], error) {
+	return NewBaseModel[T](db, opts...)
 }
 
-// Where creates a scope that adds a WHERE clause to the query.
-// It accepts the same parameters as GORM's Where method.
-func Where(query any, args ...any) Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Where(query, args...) }
-}
+// txKey is the context key for the transaction stored by WithTx.
+type txKey struct{}
 
-// WhereEq creates a scope that adds WHERE clauses for exact matches
-// using a map of column names to values.
-func WhereEq(m map[string]any) Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Where(m) }
+// WithTx returns a copy of ctx carrying tx. Repository methods called with the returned
+// context run in tx whenever their explicit tx argument is nil, so a transaction opened
+// once (e.g. in request middleware) is picked up by every call. An explicit tx argument
+// always takes precedence.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
 }
 
-// Order creates a scope that adds an ORDER BY clause to the query.
-func Order(order string) Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Order(order) }
+// InTx returns the transaction carried by ctx (see WithTx) and whether there is one, so
+// custom repository code can take part in the ambient transaction:
+//
+//	db, ok := gormplus.InTx(ctx)
+//	if !ok {
+//		db = defaultDB
+//	}
+//	err := db.WithContext(ctx).Exec("UPDATE ...").Error
+func InTx(ctx context.Context) (*gorm.DB, bool) {
+	tx := txFromContext(ctx)
+	return tx, tx != nil
 }
 
-// Select creates a scope that specifies which columns to select.
-func Select(cols ...string) Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Select(cols) }
+// txFromContext returns the transaction stored in ctx by WithTx, or nil.
+func txFromContext(ctx context.Context) *gorm.DB {
+	tx, _ := ctx.Value(txKey{}).(*gorm.DB)
+	return tx
 }
 
-// Limit creates a scope that limits the number of returned records.
-func Limit(n int) Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Limit(n) }
+// primaryKey is the context key set by UsePrimary.
+type primaryKey struct{}
+
+// UsePrimary returns a copy of ctx that pins reads to the primary even when the base model
+// has read replicas (see WithReplicas), for read-after-write paths that must see a row just
+// written despite replica lag:
+//
+//	err := userBaseModel.Create(ctx, nil, user)
+//	user, err = userBaseModel.First(gormplus.UsePrimary(ctx), gormplus.Where("id = ?", user.ID))
+func UsePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
 }
 
-// Offset creates a scope that skips the specified number of records.
-func Offset(n int) Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Offset(n) }
+// TableName returns the table queried by the base model: the WithTableName override, if any,
+// otherwise the table GORM derives from T.
+func (r *BaseModel[T]) TableName() string {
+	if r.opts.table != "" {
+		return r.opts.table
+	}
+	return r.schema.Table
 }
 
-// WithDeleted creates a scope that includes soft-deleted records in the query.
-// This is equivalent to GORM's Unscoped method.
-func WithDeleted() Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+// PrimaryKeys returns the database column names of T's primary key, in declaration order.
+// The result is empty when T has no primary key.
+func (r *BaseModel[T]) PrimaryKeys() []string {
+	return append([]string(nil), r.schema.PrimaryFieldDBNames...)
 }
 
-// OnlyDeleted creates a scope that returns only soft-deleted records.
-func OnlyDeleted() Scope {
-	return func(db *gorm.DB) *gorm.DB { return db.Unscoped().Where("deleted_at IS NOT NULL") }
+// DB returns the *gorm.DB the base model was created with, as an escape hatch for plugins,
+// association mode or migrations. Queries built from it neither see a WithTableName override
+// nor enlist in a transaction carried by a context. It shares its configuration, callbacks
+// and connection pool with the base model, so changing those is the caller's responsibility.
+func (r *BaseModel[T]) DB() *gorm.DB {
+	return r.db
 }
 
-// Create inserts a new entity into the database.
-// If tx is provided, the operation is performed within that transaction.
-// Otherwise, it uses the base model's default database connection.
-func (r *BaseModel[T]) Create(ctx context.Context, tx *gorm.DB, ent *T) error {
-	db := r.db
-	if tx != nil {
-		db = tx
+// WithSession returns a copy of the base model whose queries run in a session configured
+// by opts, such as &gorm.Session{PrepareStmt: true} for a hot path or
+// &gorm.Session{FullSaveAssociations: true}. The copy shares the parsed schema and options,
+// applying opts to replicas as well, and starts with the hooks registered so far; hooks
+// registered afterwards apply only to the base model they are registered on.
+func (r *BaseModel[T]) WithSession(opts *gorm.Session) *BaseModel[T] {
+	o := r.opts
+	o.replicas = make([]*gorm.DB, len(r.opts.replicas))
+	for i, rep := range r.opts.replicas {
+		o.replicas[i] = rep.Session(opts)
+	}
+	return &BaseModel[T]{
+		db:        r.db.Session(opts),
+		schema:    r.schema,
+		deletedAt: r.deletedAt,
+		opts:      o,
+		hooks:     r.hooks.clone(),
 	}
-	return db.WithContext(ctx).Create(ent).Error
 }
 
-// Update saves the entity to the database, updating all fields.
-// If tx is provided, the operation is performed within that transaction.
-// Otherwise, it uses the base model's default database connection.
-func (r *BaseModel[T]) Update(ctx context.Context, tx *gorm.DB, ent *T) error {
-	db := r.db
-	if tx != nil {
-		db = tx
+// Ping verifies that the database connection is alive, for example in a readiness probe.
+// It respects the deadline and cancellation of ctx.
+func (r *BaseModel[T]) Ping(ctx context.Context) (err error) {
+	defer r.observe(ctx, "Ping", time.Now(), &err)
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
 	}
-	return db.WithContext(ctx).Save(ent).Error
+	return sqlDB.PingContext(ctx)
 }
 
-// UpdateColumn updates a single column for records matching the provided scopes.
-// At least one scope must be provided to prevent accidental update of all records.
-// If tx is provided, the operation is performed within that transaction.
-func (r *BaseModel[T]) UpdateColumn(ctx context.Context, tx *gorm.DB, column string, value any, scopes ...Scope) error {
-	if len(scopes) == 0 {
-		return ErrDangerous
-	}
-	return r.scWithTX(tx, ctx, scopes...).Update(column, value).Error
+// Transact executes the provided function within a database transaction.
+// If the function returns an error, the transaction is rolled back.
+// Otherwise, the transaction is committed.
+// The context passed to fn carries the transaction (see WithTx), so repository calls made
+// with it enlist automatically. If ctx already carries a transaction, a nested transaction
+// (savepoint) is created in it.
+func (r *BaseModel[T]) Transact(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) (err error) {
+	defer r.observe(ctx, "Transact", time.Now(), &err)
+	return r.transact(ctx, nil, fn)
 }
 
-// UpdateColumns updates multiple columns for records matching the provided scopes.
-// At least one scope must be provided to prevent accidental update of all records.
-// If tx is provided, the operation is performed within that transaction.
-// The updates parameter can be a map[string]any or a struct.
-func (r *BaseModel[T]) UpdateColumns(ctx context.Context, tx *gorm.DB, updates any, scopes ...Scope) error {
-	if len(scopes) == 0 {
-		return ErrDangerous
-	}
-	return r.scWithTX(tx, ctx, scopes...).Updates(updates).Error
+// TransactWithOptions is like Transact but begins the transaction with opts, such as
+// sql.LevelSerializable isolation or a ReadOnly snapshot for consistent reporting.
+// A nil opts uses the driver defaults. opts are ignored for nested transactions (savepoints).
+func (r *BaseModel[T]) TransactWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context, tx *gorm.DB) error) (err error) {
+	defer r.observe(ctx, "TransactWithOptions", time.Now(), &err)
+	return r.transact(ctx, opts, fn)
 }
 
-// Delete removes records from the database based on the provided conditions.
-// At least one scope must be provided to prevent accidental deletion of all records.
-// If tx is provided, the operation is performed within that transaction.
-func (r *BaseModel[T]) Delete(ctx context.Context, tx *gorm.DB, scopes ...Scope) error {
-	if len(scopes) == 0 {
-		return ErrDangerous
-	}
-	return r.scWithTX(tx, ctx, scopes...).Delete(new(T)).Error
+// transact implements TransactWithOptions.
+func (r *BaseModel[T]) transact(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context, tx *gorm.DB) error) error {
+	return r.conn(ctx, nil).WithContext(ctx).Transaction(func(tx *gorm.DB) error { return fn(WithTx(ctx, tx), tx) }, opts)
 }
 
-// BatchInsert performs a batch insert operation for multiple entities.
-// If tx is provided, the operation is performed within that transaction.
-// The optional batchSize parameter controls how many records are inserted in each batch.
-// If not specified or zero, defaults to 1000 records per batch.
-func (r *BaseModel[T]) BatchInsert(ctx context.Context, tx *gorm.DB, ents []*T, batchSize ...int) error {
-	if len(ents) == 0 {
-		return nil
-	}
-	db := r.db
-	if tx != nil {
-		db = tx
+// TransactWithRetry is like Transact but re-runs the whole transaction when it fails with a
+// retryable serialization failure or deadlock (PostgreSQL SQLSTATE 40001/40P01, MySQL error
+// 1213), up to maxRetries additional attempts with exponential backoff between them.
+// Other errors are returned immediately; when retries are exhausted the last error is returned.
+// fn may therefore run more than once and should be free of side effects outside the database.
+// If ctx already carries a transaction (see WithTx), fn is not retried, since a failure aborts
+// the enclosing transaction as well.
+func (r *BaseModel[T]) TransactWithRetry(ctx context.Context, maxRetries int, fn func(ctx context.Context, tx *gorm.DB) error) (err error) {
+	defer r.observe(ctx, "TransactWithRetry", time.Now(), &err)
+	if txFromContext(ctx) != nil {
+		return r.transact(ctx, nil, fn)
 	}
 
-	size := 1000
-	if len(batchSize) > 0 {
-		size = batchSize[0]
-	}
-	if size == 0 {
-		size = 1000
+	backoff := 10 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err := r.transact(ctx, nil, fn)
+		if err == nil || !isRetryable(err) || attempt >= maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
 	}
-	return db.WithContext(ctx).CreateInBatches(ents, size).Error
 }
 
-// First retrieves the first record that matches the provided scopes.
-// Returns ErrNotFound if no record is found.
-func (r *BaseModel[T]) First(ctx context.Context, scopes ...Scope) (T, error) {
-	var out T
-	if err := r.sc(ctx, scopes...).First(&out).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return out, ErrNotFound
+// TransactWithLockTimeout is like Transact but bounds how long statements in fn wait for row
+// locks held by other transactions, so a stuck transaction elsewhere makes FOR UPDATE fail
+// instead of blocking indefinitely. On PostgreSQL it issues SET LOCAL lock_timeout (in
+// milliseconds); on MySQL it sets innodb_lock_wait_timeout (rounded up to whole seconds) for
+// the session and restores the previous value afterwards. Other dialects return ErrUnsupported
+// without running fn. When ctx already carries a transaction, the PostgreSQL setting lasts
+// until that enclosing transaction ends.
+func (r *BaseModel[T]) TransactWithLockTimeout(ctx context.Context, d time.Duration, fn func(ctx context.Context, tx *gorm.DB) error) (err error) {
+	defer r.observe(ctx, "TransactWithLockTimeout", time.Now(), &err)
+	return r.transact(ctx, nil, func(ctx context.Context, tx *gorm.DB) error {
+		switch name := tx.Dialector.Name(); name {
+		case "postgres":
+			ms := d.Milliseconds()
+			if ms < 1 {
+				ms = 1
+			}
+			if err := tx.Exec(fmt.Sprintf("SET LOCAL lock_timeout = %d", ms)).Error; err != nil {
+				return err
+			}
+		case "mysql":
+			secs := int64((d + time.Second - 1) / time.Second)
+			if secs < 1 {
+				secs = 1
+			}
+			var prev int64
+			if err := tx.Raw("SELECT @@SESSION.innodb_lock_wait_timeout").Scan(&prev).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(fmt.Sprintf("SET SESSION innodb_lock_wait_timeout = %d", secs)).Error; err != nil {
+				return err
+			}
+			defer tx.Session(&gorm.Session{Context: context.Background()}).
+				Exec(fmt.Sprintf("SET SESSION innodb_lock_wait_timeout = %d", prev))
+		default:
+			return fmt.Errorf("%w: %s: lock timeout", ErrUnsupported, name)
 		}
-		return out, err
-	}
-	return out, nil
+		return fn(ctx, tx)
+	})
 }
 
-// List retrieves all records that match the provided scopes.
-// Consider using Limit and Order scopes to control the result set size and ordering.
-func (r *BaseModel[T]) List(ctx context.Context, scopes ...Scope) ([]T, error) {
-	var out []T
-	if err := r.sc(ctx, scopes...).Find(&out).Error; err != nil {
-		return nil, err
+// isRetryable reports whether err is a transaction serialization failure or deadlock that
+// can succeed when the transaction is run again. Driver errors are detected without importing
+// the drivers: PostgreSQL errors (pgx, lib/pq) expose SQLState(), MySQL errors a Number field.
+func isRetryable(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(interface{ SQLState() string }); ok {
+			switch e.SQLState() {
+			case "40001", "40P01":
+				return true
+			}
+		}
+		rv := reflect.ValueOf(err)
+		if rv.Kind() == reflect.Pointer {
+			rv = rv.Elem()
+		}
+		if rv.Kind() == reflect.Struct {
+			if n := rv.FieldByName("Number"); n.IsValid() && n.CanUint() && n.Uint() == 1213 {
+				return true
+			}
+		}
 	}
-	return out, nil
+	return false
 }
 
-// Count returns the number of records that match the provided scopes.
-func (r *BaseModel[T]) Count(ctx context.Context, scopes ...Scope) (int64, error) {
-	var total int64
-	if err := r.sc(ctx, scopes...).Count(&total).Error; err != nil {
-		return 0, err
-	}
-	return total, nil
+// constraintError marks a driver error as an instance of sentinel while keeping it unwrappable.
+type constraintError struct {
+	sentinel error
+	err      error
 }
 
-// Exists checks whether any record matching the provided scopes exists.
-// Returns true if at least one record exists, false otherwise.
-func (r *BaseModel[T]) Exists(ctx context.Context, scopes ...Scope) (bool, error) {
-	var count int64
-	err := r.sc(ctx, scopes...).Limit(1).Count(&count).Error
-	if err != nil {
-		return false, err
+func (e *constraintError) Error() string        { return e.sentinel.Error() + ": " + e.err.Error() }
+func (e *constraintError) Is(target error) bool { return target == e.sentinel }
+func (e *constraintError) Unwrap() error        { return e.err }
+
+// translateError wraps foreign key violations in ErrForeignKeyViolation and returns other
+// errors unchanged. Like isRetryable it detects driver errors without importing the drivers:
+// PostgreSQL SQLSTATE 23503, MySQL errors 1451/1452 and SQLite extended code 787, as well as
+// gorm.ErrForeignKeyViolated from dialectors opened with TranslateError.
+func translateError(err error) error {
+	if err == nil || errors.Is(err, ErrForeignKeyViolation) {
+		return err
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return &constraintError{sentinel: ErrForeignKeyViolation, err: err}
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if s, ok := e.(interface{ SQLState() string }); ok && s.SQLState() == "23503" {
+			return &constraintError{sentinel: ErrForeignKeyViolation, err: err}
+		}
+		if c, ok := e.(interface{ Code() int }); ok && c.Code() == 787 {
+			return &constraintError{sentinel: ErrForeignKeyViolation, err: err}
+		}
+		rv := reflect.ValueOf(e)
+		if rv.Kind() == reflect.Pointer {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			continue
+		}
+		if n := rv.FieldByName("Number"); n.IsValid() && n.CanUint() && (n.Uint() == 1451 || n.Uint() == 1452) {
+			return &constraintError{sentinel: ErrForeignKeyViolation, err: err}
+		}
+		if n := rv.FieldByName("ExtendedCode"); n.IsValid() && n.CanInt() && n.Int() == 787 {
+			return &constraintError{sentinel: ErrForeignKeyViolation, err: err}
+		}
 	}
-	return count > 0, nil
+	return err
 }
 
-// FirstForUpdate retrieves the first record that matches the provided scopes
-// with a SELECT FOR UPDATE lock. This method requires a transaction to be provided.
-// Returns ErrNotFound if no record is found, ErrTxRequired if no transaction is provided.
-func (r *BaseModel[T]) FirstForUpdate(ctx context.Context, tx *gorm.DB, scopes ...Scope) (T, error) {
-	var zero T
-	if tx == nil {
-		return zero, ErrTxRequired
+// ExecuteBatch executes the write operations in order within a single transaction.
+// If any operation fails, the whole batch is rolled back and the error of the failing
+// operation is returned, annotated with its index. The context is checked between
+// operations, so cancelling it stops the batch and rolls it back.
+func (r *BaseModel[T]) ExecuteBatch(ctx context.Context, ops []WriteOp[T]) (err error) {
+	defer r.observe(ctx, "ExecuteBatch", time.Now(), &err)
+	if len(ops) == 0 {
+		return nil
 	}
-
-	scopes = append(scopes, func(d *gorm.DB) *gorm.DB {
-		return d.Clauses(clause.Locking{Strength: "UPDATE"})
+	return r.transact(ctx, nil, func(ctx context.Context, tx *gorm.DB) error {
+		for i, op := range ops {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var err error
+			switch op.Kind {
+			case WriteCreate:
+				err = r.Create(ctx, tx, op.Entity)
+			case WriteUpdate:
+				err = r.Update(ctx, tx, op.Entity)
+			case WriteDelete:
+				err = r.Delete(ctx, tx, op.Scopes...)
+			default:
+				err = fmt.Errorf("unknown write operation kind %d", op.Kind)
+			}
+			if err != nil {
+				return fmt.Errorf("write op %d: %w", i, err)
+			}
+		}
+		return nil
 	})
+}
 
-	var v T
-	if err := r.scWithTX(tx, ctx, scopes...).First(&v).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return zero, ErrNotFound
+// Where creates a scope that adds a WHERE clause to the query.
+// It accepts the same parameters as GORM's Where method.
+func Where(query any, args ...any) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Where(query, args...) }
+}
+
+// OrWhere creates a scope that adds an OR condition to the query.
+// It accepts the same parameters as GORM's Or method.
+func OrWhere(query any, args ...any) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Or(query, args...) }
+}
+
+// WhereGroup creates a scope that wraps the conditions of the given scopes in parentheses,
+// making operator precedence explicit when mixing AND and OR:
+//
+//	Where("active = ?", true), WhereGroup(Where("role = ?", "admin"), OrWhere("age > ?", 60))
+//
+// produces active = true AND (role = 'admin' OR age > 60).
+// Only the conditions of the nested scopes are used.
+func WhereGroup(scopes ...Scope) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		group := db.Session(&gorm.Session{NewDB: true})
+		for _, s := range scopes {
+			if s != nil {
+				group = s(group)
+			}
 		}
-		return zero, err
+		return db.Where(group)
 	}
-	return v, nil
 }
 
-// FindForUpdate retrieves all records that match the provided scopes
-// with a SELECT FOR UPDATE lock. This method requires a transaction to be provided.
-// Returns ErrTxRequired if no transaction is provided.
-func (r *BaseModel[T]) FindForUpdate(ctx context.Context, tx *gorm.DB, scopes ...Scope) ([]T, error) {
-	var zero []T
-	if tx == nil {
-		return zero, ErrTxRequired
+// WhereEq creates a scope that adds WHERE clauses for exact matches
+// using a map of column names to values.
+func WhereEq(m map[string]any) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Where(m) }
+}
+
+// WhereEqNonZero is like WhereEq but drops entries whose value is nil or the zero value of
+// its type (empty string, 0, false, zero time.Time), which suits optional filters built from
+// request parameters. A non-nil pointer is kept even if it points to a zero value, so
+// explicit filters such as "age = 0" can still be expressed. If every entry is dropped the
+// query is left unchanged.
+func WhereEqNonZero(m map[string]any) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		filtered := make(map[string]any, len(m))
+		for k, v := range m {
+			if v == nil || reflect.ValueOf(v).IsZero() {
+				continue
+			}
+			filtered[k] = v
+		}
+		if len(filtered) == 0 {
+			return db
+		}
+		return db.Where(filtered)
 	}
+}
 
-	scopes = append(scopes, func(d *gorm.DB) *gorm.DB {
-		return d.Clauses(clause.Locking{Strength: "UPDATE"})
-	})
+// likeEscaper escapes LIKE wildcards using '!' as the escape character,
+// which needs no special handling in the string literals of any supported dialect.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
 
-	var out []T
-	if err := r.scWithTX(tx, ctx, scopes...).Find(&out).Error; err != nil {
-		return nil, err
-	}
-	return out, nil
+// Like creates a scope that matches rows whose column contains the given substring.
+// Wildcards in substr are escaped, so it is safe to pass user input directly.
+func Like(column, substr string) Scope {
+	return like(column, "%"+likeEscaper.Replace(substr)+"%", true, false)
 }
 
-// Page retrieves a paginated result set based on the provided scopes.
-// Page numbers are 1-based. If page <= 0, defaults to 1.
-// If pageSize <= 0, defaults to 20. Maximum pageSize is capped at 1000.
-func (r *BaseModel[T]) Page(ctx context.Context, page, pageSize int, scopes ...Scope) (PageResult[T], error) {
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 {
-		pageSize = 20
-	}
-	// Cap the page size to prevent excessive resource usage
-	if pageSize > 1000 {
-		pageSize = 1000
-	}
+// LikeRaw creates a scope that matches column against a LIKE pattern as-is,
+// leaving the caller in control of the % and _ wildcards.
+func LikeRaw(column, pattern string) Scope {
+	return like(column, pattern, false, false)
+}
 
-	// First, get the total count
-	total, err := r.Count(ctx, scopes...)
-	if err != nil {
-		return PageResult[T]{}, err
+// ILike creates a case-insensitive variant of Like.
+// It emits ILIKE on PostgreSQL and compares lowercased values with LIKE elsewhere.
+func ILike(column, substr string) Scope {
+	return like(column, "%"+likeEscaper.Replace(substr)+"%", true, true)
+}
+
+// ILikeRaw creates a case-insensitive variant of LikeRaw.
+func ILikeRaw(column, pattern string) Scope {
+	return like(column, pattern, false, true)
+}
+
+func like(column, pattern string, escaped, insensitive bool) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		var sql string
+		switch {
+		case !insensitive:
+			sql = "? LIKE ?"
+		case db.Dialector.Name() == "postgres":
+			sql = "? ILIKE ?"
+		default:
+			sql = "LOWER(?) LIKE LOWER(?)"
+		}
+		if escaped {
+			sql += " ESCAPE '!'"
+		}
+		return db.Where(sql, clause.Column{Name: column}, pattern)
 	}
+}
 
-	// Then, fetch the data for the current page
+// WhereSubquery creates a scope that compares column with the result of the subquery sub,
+// built with another base model's DB or any *gorm.DB:
+//
+//	bigSpenders := orderBaseModel.DB().Model(&Order{}).Select("user_id").Where("total > ?", 100)
+//	users, err := userBaseModel.List(ctx, gormplus.WhereSubquery("id", "IN", bigSpenders))
+//
+// op is IN or NOT IN for a set of values, or one of =, <>, !=, <, <=, > and >= for a scalar
+// subquery. Other operators are rejected with ErrInvalidScope.
+func WhereSubquery(column, op string, sub *gorm.DB) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		switch op = strings.ToUpper(strings.TrimSpace(op)); op {
+		case "IN", "NOT IN", "=", "<>", "!=", "<", "<=", ">", ">=":
+		default:
+			db.AddError(fmt.Errorf("%w: unknown subquery operator %q", ErrInvalidScope, op))
+			return db
+		}
+		return db.Where("? "+op+" (?)", clause.Column{Name: column}, sub)
+	}
+}
+
+// WhereArrayContains creates a scope that matches rows whose PostgreSQL array column contains
+// every element of values, as column @> values:
+//
+//	tagged, err := postBaseModel.List(ctx, gormplus.WhereArrayContains("tags", []string{"go", "sql"}))
+//
+// values is a slice bound as a single array parameter; pgx encodes Go slices natively, while
+// with lib/pq it must be wrapped with pq.Array. Other dialects fail with ErrUnsupported, and
+// values that are not a slice or array are rejected with ErrInvalidScope.
+func WhereArrayContains(column string, values any) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if name := db.Dialector.Name(); name != "postgres" {
+			db.AddError(fmt.Errorf("%w: %s: array containment", ErrUnsupported, name))
+			return db
+		}
+		if _, ok := values.(driver.Valuer); !ok {
+			if k := reflect.ValueOf(values).Kind(); k != reflect.Slice && k != reflect.Array {
+				db.AddError(fmt.Errorf("%w: array containment needs a slice, got %T", ErrInvalidScope, values))
+				return db
+			}
+			// GORM expands bare slices into (a, b, c); bind the array as one value instead
+			values = arrayValue{values}
+		}
+		return db.Where("? @> ?", clause.Column{Name: column}, values)
+	}
+}
+
+// arrayValue binds a Go slice as a single driver argument.
+type arrayValue struct{ v any }
+
+// Value implements driver.Valuer.
+func (a arrayValue) Value() (driver.Value, error) { return a.v, nil }
+
+// Joins creates a scope that adds a JOIN clause to the query, forwarding to GORM's Joins.
+// It accepts either a raw join such as "JOIN orders ON orders.user_id = users.id" with
+// optional args, or an association name. Joining a to-many relation multiplies parent rows;
+// Count accounts for that, but List and friends need DISTINCT or GROUP BY to avoid duplicates.
+func Joins(query string, args ...any) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Joins(query, args...) }
+}
+
+// Preload creates a scope that eager-loads the named association, including nested
+// associations such as "Orders.Items". Optional args are conditions for the association
+// query, accepted the same way as GORM's Preload; Scope values may be passed as well.
+func Preload(association string, args ...any) Scope {
+	conds := make([]any, len(args))
+	for i, a := range args {
+		if s, ok := a.(Scope); ok {
+			a = (func(*gorm.DB) *gorm.DB)(s)
+		}
+		conds[i] = a
+	}
+	return func(db *gorm.DB) *gorm.DB { return db.Preload(association, conds...) }
+}
+
+// Order creates a scope that adds an ORDER BY clause to the query.
+// A blank order is rejected with ErrInvalidScope.
+func Order(order string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if strings.TrimSpace(order) == "" {
+			db.AddError(fmt.Errorf("%w: empty order", ErrInvalidScope))
+			return db
+		}
+		return db.Order(order)
+	}
+}
+
+// Select creates a scope that specifies which columns to select.
+func Select(cols ...string) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Select(cols) }
+}
+
+// Omit creates a scope that excludes columns, forwarding to GORM's Omit. On reads it loads
+// every column but the omitted ones, such as a large blob; on UpdateColumns and UpdateAll
+// with a struct it leaves the omitted columns unwritten.
+func Omit(cols ...string) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Omit(cols...) }
+}
+
+// SelectExpr creates a scope that selects a SQL expression with bound arguments, forwarding
+// to GORM's Select(query, args...), for computed columns such as
+// SelectExpr("name, COALESCE(nickname, ?) AS display", "anonymous") or window functions such
+// as SelectExpr("id, RANK() OVER (PARTITION BY user_id ORDER BY total DESC) AS rnk").
+// Combine it with Scan or ScanInto to read the projection into a DTO; to filter on a window
+// function, see TopPerGroup.
+func SelectExpr(query string, args ...any) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Select(query, args...) }
+}
+
+// distinctRow is the selection Distinct uses for whole rows of the model.
+const distinctRow = "?.*"
+
+// Distinct creates a scope that selects distinct rows.
+// With no columns it emits a plain SELECT DISTINCT over the model's table; with columns it selects distinct
+// values of those columns. Combined with Count and a single column it counts
+// COUNT(DISTINCT column).
+func Distinct(cols ...string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(cols) == 0 {
+			// GORM drops DISTINCT for a bare SELECT *, so qualify the model's columns
+			return db.Distinct(distinctRow, clause.Table{Name: clause.CurrentTable})
+		}
+		return db.Distinct(cols)
+	}
+}
+
+// GroupBy creates a scope that adds a GROUP BY clause for the given columns.
+func GroupBy(cols ...string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, c := range cols {
+			db = db.Group(c)
+		}
+		return db
+	}
+}
+
+// Having creates a scope that adds a HAVING clause to a grouped query.
+// It accepts the same parameters as GORM's Having method.
+func Having(query any, args ...any) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Having(query, args...) }
+}
+
+// Limit creates a scope that limits the number of returned records.
+func Limit(n int) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Limit(n) }
+}
+
+// Offset creates a scope that skips the specified number of records.
+func Offset(n int) Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Offset(n) }
+}
+
+// WithTimeout creates a scope that bounds the query to d by running it with a child of the
+// query's context carrying that deadline. Every base model method already runs with the
+// context it is given, so wrapping ctx with context.WithTimeout is equivalent; the scope is
+// a shorthand for bounding a single call. When the deadline passes, the drivers abort the
+// query: pgx and lib/pq send a cancel request so PostgreSQL stops executing it, and SQLite
+// interrupts it. The MySQL driver closes the connection instead, and the server may keep
+// running the statement until it notices; use MAX_EXECUTION_TIME there for heavy queries.
+//
+// The child context is released by a callback, registered by NewBaseModel, as soon as the
+// statement has run. Preloaded associations are queried while the statement runs, so d
+// bounds them too and they are released with it; FindInBatches bounds all of its batches
+// together and releases the context when it returns. Reads that hand rows back to the caller
+// before they are consumed (Iterate, and the Scan-based Exists, CountDistinct, aggregates,
+// Scan and ScanInto) cannot be released that way and hold the timer until d elapses; for
+// those on hot paths, prefer context.WithTimeout with a deferred cancel.
+func WithTimeout(d time.Duration) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		parent := db.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(parent, d)
+		tc := &timeoutCancel{cancel: cancel}
+		db = db.Set(timeoutKey, tc)
+		tc.stmt = db.Statement
+		db.Statement.Context = ctx
+		return db
+	}
+}
+
+// timeoutKey is the statement setting holding the timeoutCancel of a WithTimeout scope.
+const timeoutKey = "gormplus:timeout"
+
+// timeoutCancel releases the context of a WithTimeout scope once stmt has run. Statements
+// cloned from stmt, such as those preloading associations, inherit the setting but do not
+// release it.
+type timeoutCancel struct {
+	stmt   *gorm.Statement
+	cancel context.CancelFunc
+}
+
+// releaseTimeout is the callback releasing the context of a WithTimeout scope.
+func releaseTimeout(db *gorm.DB) {
+	if v, ok := db.Get(timeoutKey); ok {
+		if tc, ok := v.(*timeoutCancel); ok && tc.stmt == db.Statement {
+			tc.cancel()
+		}
+	}
+}
+
+// cancelTimeout releases the context of a WithTimeout scope applied to db, for methods that
+// run several statements cloned from db and are done with all of them.
+func cancelTimeout(db *gorm.DB) {
+	if v, ok := db.Get(timeoutKey); ok {
+		if tc, ok := v.(*timeoutCancel); ok {
+			tc.cancel()
+		}
+	}
+}
+
+// registerTimeoutRelease registers releaseTimeout after every callback of the processors
+// that are done with the context once they return. Rows-returning processors are left out,
+// since the caller still reads from the rows. Registration happens once per callback set.
+func registerTimeoutRelease(db *gorm.DB) error {
+	const name = "gormplus:release_timeout"
+	cb := db.Callback()
+	if cb.Query().Get(name) != nil {
+		return nil
+	}
+	for _, err := range []error{
+		cb.Create().After("*").Register(name, releaseTimeout),
+		cb.Query().After("*").Register(name, releaseTimeout),
+		cb.Update().After("*").Register(name, releaseTimeout),
+		cb.Delete().After("*").Register(name, releaseTimeout),
+		cb.Raw().After("*").Register(name, releaseTimeout),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithDeleted creates a scope that includes soft-deleted records in the query.
+// This is equivalent to GORM's Unscoped method.
+func WithDeleted() Scope {
+	return func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+}
+
+// OnlyDeleted creates a scope that returns only soft-deleted records.
+// The soft-delete column is read from the query's model, so fields mapped to a column
+// other than deleted_at are supported; deleted_at is assumed when there is no model.
+// Like the OnlyDeleted method, it fails the query with ErrUnsupported if the model has no
+// soft delete.
+func OnlyDeleted() Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		column, ok := deletedAtColumn(db)
+		if !ok {
+			db.AddError(fmt.Errorf("%w: model %s has no soft-delete field", ErrUnsupported, db.Statement.Schema.Name))
+			return db
+		}
+		return db.Unscoped().Where(clause.Expr{
+			SQL:  "? IS NOT NULL",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: column}},
+		})
+	}
+}
+
+// NotDeleted creates a scope that returns only records that are not soft-deleted, filtering
+// on the soft-delete column IS NULL. It is what GORM does by default, made explicit for
+// queries where the default is bypassed, such as after WithDeleted or in HardDelete.
+// The column is resolved as in OnlyDeleted; like the NotDeleted method, it fails the query
+// with ErrUnsupported if the model has no soft delete.
+func NotDeleted() Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		column, ok := deletedAtColumn(db)
+		if !ok {
+			db.AddError(fmt.Errorf("%w: model %s has no soft-delete field", ErrUnsupported, db.Statement.Schema.Name))
+			return db
+		}
+		return db.Where(clause.Expr{
+			SQL:  "? IS NULL",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: column}},
+		})
+	}
+}
+
+// ActiveOnly is NotDeleted under a name that states intent at call sites where returning
+// only live records matters, even though it is the default.
+func ActiveOnly() Scope {
+	return NotDeleted()
+}
+
+// deletedAtColumn returns the soft-delete column of the query's model, or deleted_at when
+// the query has no model. ok is false if the model has no soft-delete field.
+func deletedAtColumn(db *gorm.DB) (column string, ok bool) {
+	model := db.Statement.Model
+	if model == nil {
+		model = db.Statement.Dest
+	}
+	if model == nil || db.Statement.Parse(model) != nil {
+		return "deleted_at", true
+	}
+	if f := softDeleteField(db.Statement.Schema); f != nil {
+		return f.DBName, true
+	}
+	return "deleted_at", false
+}
+
+// OnlyDeleted creates a scope that returns only soft-deleted records, using the soft-delete
+// field of the model's schema captured at construction. Unlike the package-level OnlyDeleted
+// it does not depend on the query's model; like it, it fails the query with ErrUnsupported
+// if T has no soft delete.
+func (r *BaseModel[T]) OnlyDeleted() Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if r.deletedAt == nil {
+			db.AddError(fmt.Errorf("%w: model %s has no soft-delete field", ErrUnsupported, r.schema.Name))
+			return db
+		}
+		return db.Unscoped().Where(clause.Expr{
+			SQL:  "? IS NOT NULL",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: r.deletedAt.DBName}},
+		})
+	}
+}
+
+// NotDeleted creates a scope that returns only records that are not soft-deleted, using the
+// soft-delete field of the model's schema captured at construction. Like the OnlyDeleted
+// method and the package-level NotDeleted, it fails the query with ErrUnsupported if T has no
+// soft delete.
+func (r *BaseModel[T]) NotDeleted() Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if r.deletedAt == nil {
+			db.AddError(fmt.Errorf("%w: model %s has no soft-delete field", ErrUnsupported, r.schema.Name))
+			return db
+		}
+		return db.Where(clause.Expr{
+			SQL:  "? IS NULL",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: r.deletedAt.DBName}},
+		})
+	}
+}
+
+// ExcludeIDs creates a scope that excludes records whose primary key is one of ids.
+// An empty ids list leaves the query unchanged.
+func (r *BaseModel[T]) ExcludeIDs(ids ...any) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(ids) == 0 {
+			return db
+		}
+		pk := r.schema.PrioritizedPrimaryField
+		if pk == nil {
+			db.AddError(ErrNoPrimaryKey)
+			return db
+		}
+		return db.Where(clause.Not(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Values: ids}))
+	}
+}
+
+// OrderBy creates a scope that sorts by column, which may be given by database or struct
+// field name. The column is validated against the model schema and quoted by the dialector,
+// so it is safe to take from API input; an unknown column fails the query with
+// ErrInvalidColumn.
+func (r *BaseModel[T]) OrderBy(column string, desc bool) Scope {
+	return r.OrderByColumns(OrderSpec{Column: column, Desc: desc})
+}
+
+// OrderSpec describes one sort key of OrderByColumns.
+type OrderSpec struct {
+	Column string
+	Desc   bool
+}
+
+// OrderByColumns creates a scope that sorts by several columns in the given precedence,
+// emitted as a single ORDER BY. Every column is validated and quoted as for OrderBy.
+// No specs leave the query unchanged.
+func (r *BaseModel[T]) OrderByColumns(specs ...OrderSpec) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(specs) == 0 {
+			return db
+		}
+		cols := make([]clause.OrderByColumn, 0, len(specs))
+		for _, spec := range specs {
+			f := r.schema.LookUpField(spec.Column)
+			if f == nil || f.DBName == "" {
+				db.AddError(fmt.Errorf("%w: %s", ErrInvalidColumn, spec.Column))
+				return db
+			}
+			cols = append(cols, clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Desc: spec.Desc})
+		}
+		return db.Order(clause.OrderBy{Columns: cols})
+	}
+}
+
+// FilterStruct builds a scope from a filter struct whose fields are tagged with the column
+// they filter and an optional operator, e.g.
+//
+//	type UserFilter struct {
+//		MinAge *int   `filter:"age,op=gte"`
+//		Name   string `filter:"name,op=like"`
+//		IDs    []uint `filter:"id,op=in"`
+//	}
+//
+// Supported operators are eq (the default), neq, gt, gte, lt, lte, like and ilike (substring
+// matches as Like and ILike) and in. Nil pointers, zero values and empty slices are skipped;
+// a non-nil pointer contributes even when it points to a zero value. Untagged fields and
+// fields tagged "-" are ignored. Every tagged column is validated against the model schema
+// and ErrInvalidColumn is returned for unknown columns, ErrInvalidScope for unknown operators.
+func (r *BaseModel[T]) FilterStruct(filter any) (Scope, error) {
+	rv := reflect.ValueOf(filter)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("filter must be a struct, got %T", filter)
+	}
+
+	var scopes []Scope
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag, ok := sf.Tag.Lookup("filter")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		name, op := parseFilterTag(tag)
+		f := r.schema.LookUpField(name)
+		if f == nil || f.DBName == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidColumn, name)
+		}
+
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		} else if fv.IsZero() {
+			continue
+		}
+
+		scope, err := filterScope(f.DBName, op, sf.Name, fv)
+		if err != nil {
+			return nil, err
+		}
+		if scope != nil {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		for _, s := range scopes {
+			db = s(db)
+		}
+		return db
+	}, nil
+}
+
+// WhereStruct creates a scope from the non-zero fields of a filter struct, so a populated
+// request DTO can be passed straight to List or Page. Each field is matched by equality on
+// the column named by its gorm column tag, or by the naming strategy (name -> name, UserID
+// -> user_id). A filter tag as understood by FilterStruct, e.g. `filter:"age,op=gte"`,
+// overrides the column and operator. Nil pointers, zero values and empty slices are skipped;
+// fields tagged filter:"-" or gorm:"-" and unexported fields are ignored. Unlike FilterStruct
+// the columns are not validated against a model; unknown operators fail the query with
+// ErrInvalidScope.
+func WhereStruct(filter any) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		rv := reflect.ValueOf(filter)
+		if rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return db
+			}
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			db.AddError(fmt.Errorf("%w: filter must be a struct, got %T", ErrInvalidScope, filter))
+			return db
+		}
+
+		rt := rv.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			sf := rt.Field(i)
+			if !sf.IsExported() || sf.Tag.Get("gorm") == "-" {
+				continue
+			}
+			column, op := "", "eq"
+			if tag, ok := sf.Tag.Lookup("filter"); ok {
+				if tag == "-" {
+					continue
+				}
+				column, op = parseFilterTag(tag)
+			} else if c := schema.ParseTagSetting(sf.Tag.Get("gorm"), ";")["COLUMN"]; c != "" {
+				column = c
+			} else {
+				column = db.NamingStrategy.ColumnName("", sf.Name)
+			}
+
+			fv := rv.Field(i)
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			} else if fv.IsZero() {
+				continue
+			}
+
+			scope, err := filterScope(column, op, sf.Name, fv)
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			if scope != nil {
+				db = scope(db)
+			}
+		}
+		return db
+	}
+}
+
+// filterScope builds the condition of a filter field with value fv on column. It returns a nil
+// scope for an in filter with no values.
+func filterScope(column, op, fieldName string, fv reflect.Value) (Scope, error) {
+	col := clause.Column{Table: clause.CurrentTable, Name: column}
+	var expr clause.Expression
+	switch op {
+	case "eq":
+		expr = clause.Eq{Column: col, Value: fv.Interface()}
+	case "neq":
+		expr = clause.Neq{Column: col, Value: fv.Interface()}
+	case "gt":
+		expr = clause.Gt{Column: col, Value: fv.Interface()}
+	case "gte":
+		expr = clause.Gte{Column: col, Value: fv.Interface()}
+	case "lt":
+		expr = clause.Lt{Column: col, Value: fv.Interface()}
+	case "lte":
+		expr = clause.Lte{Column: col, Value: fv.Interface()}
+	case "like", "ilike":
+		if fv.Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %s filter on %s requires a string field", ErrInvalidScope, op, fieldName)
+		}
+		if op == "like" {
+			return Like(column, fv.String()), nil
+		}
+		return ILike(column, fv.String()), nil
+	case "in":
+		if fv.Kind() != reflect.Slice && fv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("%w: in filter on %s requires a slice field", ErrInvalidScope, fieldName)
+		}
+		if fv.Len() == 0 {
+			return nil, nil
+		}
+		values := make([]any, fv.Len())
+		for j := range values {
+			values[j] = fv.Index(j).Interface()
+		}
+		expr = clause.IN{Column: col, Values: values}
+	default:
+		return nil, fmt.Errorf("%w: unknown filter operator %q on %s", ErrInvalidScope, op, fieldName)
+	}
+	return Where(expr), nil
+}
+
+// parseFilterTag splits a filter tag such as "age,op=gte" into its column and operator,
+// which defaults to eq.
+func parseFilterTag(tag string) (column, op string) {
+	parts := strings.Split(tag, ",")
+	column, op = strings.TrimSpace(parts[0]), "eq"
+	for _, p := range parts[1:] {
+		if p = strings.TrimSpace(p); strings.HasPrefix(p, "op=") {
+			op = strings.ToLower(strings.TrimPrefix(p, "op="))
+		}
+	}
+	return column, op
+}
+
+// AllowedFilters creates a FilterValidator that only accepts the given columns.
+// Columns may be given by database or struct field name.
+func (r *BaseModel[T]) AllowedFilters(columns ...string) *FilterValidator[T] {
+	allowed := make(map[string]struct{}, len(columns))
+	for _, c := range columns {
+		if f := r.schema.LookUpField(c); f != nil {
+			c = f.DBName
+		}
+		allowed[c] = struct{}{}
+	}
+	return &FilterValidator[T]{schema: r.schema, allowed: allowed}
+}
+
+// FilterFromMap creates an equality filter scope from a map of column names to values,
+// as WhereEq does, after checking every column against the allowlist.
+// Returns ErrInvalidColumn if any column is unknown or not allowed.
+func (v *FilterValidator[T]) FilterFromMap(m map[string]any) (Scope, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	exprs := make([]clause.Expression, 0, len(keys))
+	for _, k := range keys {
+		col, err := v.column(k)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: col}, Value: m[k]})
+	}
+	return func(db *gorm.DB) *gorm.DB { return db.Clauses(clause.Where{Exprs: exprs}) }, nil
+}
+
+// OrderFromSpec creates an ORDER BY scope from a comma-separated sort specification
+// such as "name,-created_at", where a leading '-' sorts that column descending.
+// Returns ErrInvalidColumn if any column is unknown or not allowed.
+func (v *FilterValidator[T]) OrderFromSpec(spec string) (Scope, error) {
+	var cols []clause.OrderByColumn
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		desc := strings.HasPrefix(part, "-")
+		col, err := v.column(strings.TrimPrefix(part, "-"))
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: col}, Desc: desc})
+	}
+	if len(cols) == 0 {
+		return nil, nil
+	}
+	return func(db *gorm.DB) *gorm.DB { return db.Order(clause.OrderBy{Columns: cols}) }, nil
+}
+
+// column resolves name to the model's database column and checks it against the allowlist.
+func (v *FilterValidator[T]) column(name string) (string, error) {
+	f := v.schema.LookUpField(name)
+	if f == nil || f.DBName == "" {
+		return "", fmt.Errorf("%w: %s", ErrInvalidColumn, name)
+	}
+	if _, ok := v.allowed[f.DBName]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidColumn, name)
+	}
+	return f.DBName, nil
+}
+
+// EntityHook is a callback invoked around Create, Update or UpdateWithVersion with the entity
+// being written. Hooks run in registration order and must be registered before the base
+// model is used concurrently. Bulk methods such as BatchInsert, pgcopy.CopyInsert, UpdateColumns
+// and UpdateAll do not run hooks.
+type EntityHook[T any] func(ctx context.Context, ent *T) error
+
+// DeleteHook is a callback invoked around Delete, and the other deletes built on it
+// (DeleteByIDs, DeleteAll, DeleteReturning, HardDelete and PurgeDeleted), with the scopes
+// selecting the records.
+type DeleteHook func(ctx context.Context, scopes []Scope) error
+
+// hooks holds the callbacks registered on a BaseModel.
+type hooks[T any] struct {
+	beforeCreate, afterCreate []EntityHook[T]
+	beforeUpdate, afterUpdate []EntityHook[T]
+	beforeDelete, afterDelete []DeleteHook
+}
+
+// clone returns a copy of h whose hook lists can be appended to independently of h's.
+func (h hooks[T]) clone() hooks[T] {
+	return hooks[T]{
+		beforeCreate: append([]EntityHook[T](nil), h.beforeCreate...),
+		afterCreate:  append([]EntityHook[T](nil), h.afterCreate...),
+		beforeUpdate: append([]EntityHook[T](nil), h.beforeUpdate...),
+		afterUpdate:  append([]EntityHook[T](nil), h.afterUpdate...),
+		beforeDelete: append([]DeleteHook(nil), h.beforeDelete...),
+		afterDelete:  append([]DeleteHook(nil), h.afterDelete...),
+	}
+}
+
+// OnBeforeCreate registers a hook run by Create before the insert.
+func (r *BaseModel[T]) OnBeforeCreate(h EntityHook[T]) {
+	r.hooks.beforeCreate = append(r.hooks.beforeCreate, h)
+}
+
+// OnAfterCreate registers a hook run by Create after a successful insert.
+func (r *BaseModel[T]) OnAfterCreate(h EntityHook[T]) {
+	r.hooks.afterCreate = append(r.hooks.afterCreate, h)
+}
+
+// OnBeforeUpdate registers a hook run by Update and UpdateWithVersion before the save.
+func (r *BaseModel[T]) OnBeforeUpdate(h EntityHook[T]) {
+	r.hooks.beforeUpdate = append(r.hooks.beforeUpdate, h)
+}
+
+// OnAfterUpdate registers a hook run by Update and UpdateWithVersion after a successful save.
+func (r *BaseModel[T]) OnAfterUpdate(h EntityHook[T]) {
+	r.hooks.afterUpdate = append(r.hooks.afterUpdate, h)
+}
+
+// OnBeforeDelete registers a hook run by Delete before the delete.
+func (r *BaseModel[T]) OnBeforeDelete(h DeleteHook) {
+	r.hooks.beforeDelete = append(r.hooks.beforeDelete, h)
+}
+
+// OnAfterDelete registers a hook run by Delete after a successful delete.
+func (r *BaseModel[T]) OnAfterDelete(h DeleteHook) {
+	r.hooks.afterDelete = append(r.hooks.afterDelete, h)
+}
+
+// withHooks runs op between the before and after hooks. Without hooks op runs as is;
+// otherwise everything runs in one transaction (see inTx) whose context is passed to the
+// hooks, so base model calls made by a hook with a nil tx take part in it. The first hook
+// error aborts the operation and rolls the transaction back.
+func (r *BaseModel[T]) withHooks(ctx context.Context, tx *gorm.DB, before, after func(ctx context.Context) error, op func(tx *gorm.DB) error) error {
+	if before == nil && after == nil {
+		return op(tx)
+	}
+	return r.inTx(ctx, tx, func(tx *gorm.DB) error {
+		hctx := WithTx(ctx, tx)
+		if before != nil {
+			if err := before(hctx); err != nil {
+				return err
+			}
+		}
+		if err := op(tx); err != nil {
+			return err
+		}
+		if after != nil {
+			return after(hctx)
+		}
+		return nil
+	})
+}
+
+// entityHooks combines hs into a single callback for withHooks, or nil if hs is empty.
+func entityHooks[T any](hs []EntityHook[T], ent *T) func(ctx context.Context) error {
+	if len(hs) == 0 {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		for _, h := range hs {
+			if err := h(ctx, ent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// deleteHooks combines hs into a single callback for withHooks, or nil if hs is empty.
+func deleteHooks(hs []DeleteHook, scopes []Scope) func(ctx context.Context) error {
+	if len(hs) == 0 {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		for _, h := range hs {
+			if err := h(ctx, scopes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Create inserts a new entity into the database.
+// If tx is provided, the operation is performed within that transaction.
+// Otherwise, it uses the base model's default database connection.
+// Hooks registered with OnBeforeCreate and OnAfterCreate run around the insert.
+// Foreign key violations are returned as ErrForeignKeyViolation.
+func (r *BaseModel[T]) Create(ctx context.Context, tx *gorm.DB, ent *T) (err error) {
+	defer r.observe(ctx, "Create", time.Now(), &err)
+	before, after := entityHooks(r.hooks.beforeCreate, ent), entityHooks(r.hooks.afterCreate, ent)
+	return r.withHooks(ctx, tx, before, after, func(tx *gorm.DB) error {
+		db := r.conn(ctx, tx)
+		restore, err := r.encrypt(ctx, ent)
+		if err != nil {
+			return err
+		}
+		defer restore()
+		return translateError(r.withTable(db.WithContext(ctx)).Create(ent).Error)
+	})
+}
+
+// CreateAndReload inserts ent and then re-selects the row by primary key, so that values
+// computed by the database (column defaults, triggers) are populated in ent on every dialect,
+// not only those supporting RETURNING. Both statements run in one transaction: tx if provided,
+// otherwise a new one. Associations already set on ent are left as they are.
+func (r *BaseModel[T]) CreateAndReload(ctx context.Context, tx *gorm.DB, ent *T) (err error) {
+	defer r.observe(ctx, "CreateAndReload", time.Now(), &err)
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return ErrNoPrimaryKey
+	}
+	return r.inTx(ctx, tx, func(tx *gorm.DB) error {
+		if err := r.Create(ctx, tx, ent); err != nil {
+			return err
+		}
+		id, zero := pk.ValueOf(ctx, reflect.ValueOf(ent).Elem())
+		if zero {
+			return fmt.Errorf("reload %s: primary key was not set by the insert", r.schema.Name)
+		}
+		byID := Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Value: id})
+		if err := r.scWithTX(tx, ctx, byID).First(ent).Error; err != nil {
+			return err
+		}
+		return r.decrypt(ctx, ent)
+	})
+}
+
+// CreateIfUnderLimit inserts ent only if fewer than limit records match the provided scopes,
+// such as "at most N orders per user", and reports whether it inserted. The matching records
+// are read with a SELECT FOR UPDATE lock and the insert runs in the same transaction: tx if
+// provided, otherwise a new one. Note that row locks cannot cover rows that do not exist yet;
+// for a strict guarantee on databases with row-level locking, also lock a parent row
+// (e.g. the user) or run under SERIALIZABLE isolation.
+func (r *BaseModel[T]) CreateIfUnderLimit(ctx context.Context, tx *gorm.DB, ent *T, limit int64, scopes ...Scope) (_ bool, err error) {
+	defer r.observe(ctx, "CreateIfUnderLimit", time.Now(), &err)
+	return r.createIfUnderLimit(ctx, tx, ent, limit, scopes)
+}
+
+// CreateIfNotExists inserts ent only if no record matches the provided scopes, such as when
+// seeding reference data, and reports whether it inserted. Unlike Upsert it never modifies an
+// existing record, and it needs no unique index. The check locks matching rows as in
+// CreateIfUnderLimit, with the same caveat: two concurrent callers can both see no row, so
+// back it with a unique index where duplicates must be impossible.
+// At least one scope must be provided; otherwise ErrDangerous is returned.
+func (r *BaseModel[T]) CreateIfNotExists(ctx context.Context, tx *gorm.DB, ent *T, scopes ...Scope) (created bool, err error) {
+	defer r.observe(ctx, "CreateIfNotExists", time.Now(), &err)
+	if len(scopes) == 0 {
+		return false, ErrDangerous
+	}
+	return r.createIfUnderLimit(ctx, tx, ent, 1, scopes)
+}
+
+// createIfUnderLimit implements CreateIfUnderLimit and CreateIfNotExists.
+func (r *BaseModel[T]) createIfUnderLimit(ctx context.Context, tx *gorm.DB, ent *T, limit int64, scopes []Scope) (bool, error) {
+	if limit <= 0 {
+		return false, nil
+	}
+
+	var created bool
+	run := func(tx *gorm.DB) error {
+		lock := func(d *gorm.DB) *gorm.DB {
+			d = d.Clauses(clause.Locking{Strength: "UPDATE"}).Limit(int(limit))
+			if pk := r.schema.PrioritizedPrimaryField; pk != nil {
+				d = d.Select("?", clause.Column{Table: clause.CurrentTable, Name: pk.DBName})
+			}
+			return d
+		}
+		var rows []T
+		if err := r.scWithTX(tx, ctx, append(scopes, lock)...).Find(&rows).Error; err != nil {
+			return err
+		}
+		if int64(len(rows)) >= limit {
+			return nil
+		}
+		if err := r.Create(ctx, tx, ent); err != nil {
+			return err
+		}
+		created = true
+		return nil
+	}
+	if err := r.inTx(ctx, tx, run); err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// Update saves the entity to the database, updating all fields.
+// If tx is provided, the operation is performed within that transaction.
+// Otherwise, it uses the base model's default database connection.
+// Hooks registered with OnBeforeUpdate and OnAfterUpdate run around the save.
+// Foreign key violations are returned as ErrForeignKeyViolation.
+func (r *BaseModel[T]) Update(ctx context.Context, tx *gorm.DB, ent *T) (err error) {
+	defer r.observe(ctx, "Update", time.Now(), &err)
+	_, err = r.update(ctx, tx, ent)
+	return err
+}
+
+// UpdateAffected is like Update but also returns the number of rows affected.
+func (r *BaseModel[T]) UpdateAffected(ctx context.Context, tx *gorm.DB, ent *T) (_ int64, err error) {
+	defer r.observe(ctx, "UpdateAffected", time.Now(), &err)
+	return r.update(ctx, tx, ent)
+}
+
+// update implements UpdateAffected.
+func (r *BaseModel[T]) update(ctx context.Context, tx *gorm.DB, ent *T) (int64, error) {
+	var n int64
+	before, after := entityHooks(r.hooks.beforeUpdate, ent), entityHooks(r.hooks.afterUpdate, ent)
+	err := r.withHooks(ctx, tx, before, after, func(tx *gorm.DB) error {
+		db := r.conn(ctx, tx)
+		restore, err := r.encrypt(ctx, ent)
+		if err != nil {
+			return err
+		}
+		defer restore()
+		res := r.withTable(db.WithContext(ctx)).Save(ent)
+		n = res.RowsAffected
+		return translateError(res.Error)
+	})
+	return n, err
+}
+
+// SaveAll creates the entities whose primary key is zero and updates the others, as Create
+// and Update do, all within one transaction: tx if provided, otherwise a new one.
+// If any entity fails, the whole batch is rolled back and the error is returned,
+// annotated with the entity's index.
+func (r *BaseModel[T]) SaveAll(ctx context.Context, tx *gorm.DB, ents []*T) (err error) {
+	defer r.observe(ctx, "SaveAll", time.Now(), &err)
+	if len(ents) == 0 {
+		return nil
+	}
+	if len(r.schema.PrimaryFields) == 0 {
+		return ErrNoPrimaryKey
+	}
+	return r.inTx(ctx, tx, func(tx *gorm.DB) error {
+		for i, ent := range ents {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var err error
+			if r.isNew(ctx, ent) {
+				err = r.Create(ctx, tx, ent)
+			} else {
+				err = r.Update(ctx, tx, ent)
+			}
+			if err != nil {
+				return fmt.Errorf("entity %d: %w", i, err)
+			}
+		}
+		return nil
+	})
+}
+
+// isNew reports whether every primary key field of ent is zero.
+func (r *BaseModel[T]) isNew(ctx context.Context, ent *T) bool {
+	rv := reflect.ValueOf(ent).Elem()
+	for _, f := range r.schema.PrimaryFields {
+		if _, zero := f.ValueOf(ctx, rv); !zero {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateWithVersion saves the entity using optimistic locking on versionColumn.
+// The update only applies if the stored version still equals the entity's version,
+// in which case the version is incremented on both the row and the entity.
+// Returns ErrVersionConflict if the record was changed (or removed) concurrently.
+// If tx is provided, the operation is performed within that transaction.
+// Hooks registered with OnBeforeUpdate and OnAfterUpdate run around the save.
+func (r *BaseModel[T]) UpdateWithVersion(ctx context.Context, tx *gorm.DB, ent *T, versionColumn string) (err error) {
+	defer r.observe(ctx, "UpdateWithVersion", time.Now(), &err)
+	field := r.schema.LookUpField(versionColumn)
+	if field == nil || field.DBName == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidColumn, versionColumn)
+	}
+
+	rv := reflect.ValueOf(ent).Elem()
+	var version int64
+	switch fv := reflect.Indirect(field.ReflectValueOf(ctx, rv)); fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		version = fv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		version = int64(fv.Uint())
+	default:
+		return fmt.Errorf("%w: %s must be an integer", ErrInvalidColumn, versionColumn)
+	}
+
+	before, after := entityHooks(r.hooks.beforeUpdate, ent), entityHooks(r.hooks.afterUpdate, ent)
+	err = r.withHooks(ctx, tx, before, after, func(tx *gorm.DB) error {
+		if err := field.Set(ctx, rv, version+1); err != nil {
+			return err
+		}
+		restore, err := r.encrypt(ctx, ent)
+		if err != nil {
+			return err
+		}
+		defer restore()
+		res := r.scWithTX(tx, ctx, Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: version})).
+			Model(ent).Select("*").Updates(ent)
+		if res.Error == nil && res.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return res.Error
+	})
+	if err != nil {
+		_ = field.Set(ctx, rv, version)
+		return err
+	}
+	return nil
+}
+
+// UpdateWithRetry performs an optimistic read-modify-write cycle on the record with the given
+// primary key: it loads the record, applies mutate, and saves it with UpdateWithVersion.
+// On ErrVersionConflict the record is reloaded and mutate is applied again, up to maxRetries
+// additional attempts. mutate may therefore run more than once and should be free of side effects.
+// Returns the saved entity, ErrNotFound if the record does not exist, or the last error.
+func (r *BaseModel[T]) UpdateWithRetry(ctx context.Context, id any, versionColumn string, mutate func(*T) error, maxRetries int) (_ T, err error) {
+	defer r.observe(ctx, "UpdateWithRetry", time.Now(), &err)
+	var zero T
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return zero, ErrNoPrimaryKey
+	}
+	byID := Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Value: id})
+	// Read from the primary: a lagging replica would keep returning the stale version.
+	readCtx := UsePrimary(ctx)
+
+	for attempt := 0; ; attempt++ {
+		ent, err := r.First(readCtx, byID)
+		if err != nil {
+			return zero, err
+		}
+		if err := mutate(&ent); err != nil {
+			return zero, err
+		}
+		err = r.UpdateWithVersion(ctx, nil, &ent, versionColumn)
+		if err == nil {
+			return ent, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt >= maxRetries {
+			return zero, err
+		}
+	}
+}
+
+// UpdateColumn updates a single column for records matching the provided scopes.
+// At least one scope must be provided to prevent accidental update of all records.
+// If tx is provided, the operation is performed within that transaction.
+func (r *BaseModel[T]) UpdateColumn(ctx context.Context, tx *gorm.DB, column string, value any, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "UpdateColumn", time.Now(), &err)
+	_, err = r.updateColumns(ctx, tx, map[string]any{column: value}, scopes)
+	return err
+}
+
+// UpdateColumnAffected is like UpdateColumn but also returns the number of rows affected,
+// so callers can tell whether a conditional update matched anything.
+func (r *BaseModel[T]) UpdateColumnAffected(ctx context.Context, tx *gorm.DB, column string, value any, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "UpdateColumnAffected", time.Now(), &err)
+	return r.updateColumns(ctx, tx, map[string]any{column: value}, scopes)
+}
+
+// UpdateColumns updates multiple columns for records matching the provided scopes.
+// At least one scope must be provided to prevent accidental update of all records.
+// If tx is provided, the operation is performed within that transaction.
+// The updates parameter can be a map[string]any or a struct.
+func (r *BaseModel[T]) UpdateColumns(ctx context.Context, tx *gorm.DB, updates any, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "UpdateColumns", time.Now(), &err)
+	_, err = r.updateColumns(ctx, tx, updates, scopes)
+	return err
+}
+
+// UpdateColumnsAffected is like UpdateColumns but also returns the number of rows affected,
+// so callers can tell whether a conditional update matched anything.
+func (r *BaseModel[T]) UpdateColumnsAffected(ctx context.Context, tx *gorm.DB, updates any, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "UpdateColumnsAffected", time.Now(), &err)
+	return r.updateColumns(ctx, tx, updates, scopes)
+}
+
+// updateColumns implements UpdateColumnsAffected.
+func (r *BaseModel[T]) updateColumns(ctx context.Context, tx *gorm.DB, updates any, scopes []Scope) (int64, error) {
+	if len(scopes) == 0 {
+		return 0, ErrDangerous
+	}
+	res := r.scWithTX(tx, ctx, scopes...).Updates(updates)
+	return res.RowsAffected, res.Error
+}
+
+// Touch sets the model's auto-update timestamp column (such as UpdatedAt) to the current time
+// for records matching the provided scopes, leaving every other column unchanged. The value
+// is stored the way GORM's autoUpdateTime would store it, including Unix second, milli or
+// nanosecond integer columns. At least one scope must be provided to prevent accidental
+// update of all records. If tx is provided, the operation is performed within that transaction.
+// Models without an auto-update timestamp field return ErrUnsupported.
+func (r *BaseModel[T]) Touch(ctx context.Context, tx *gorm.DB, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "Touch", time.Now(), &err)
+	field := r.updatedAtField()
+	if field == nil {
+		return fmt.Errorf("%w: model %s has no auto-update timestamp field", ErrUnsupported, r.schema.Name)
+	}
+	_, err = r.updateColumns(ctx, tx, map[string]any{field.DBName: r.autoUpdateNow(field)}, scopes)
+	return err
+}
+
+// autoUpdateNow returns the DB's NowFunc time in the representation of the given
+// auto-update timestamp field: Unix seconds, milliseconds, nanoseconds or a time.Time.
+func (r *BaseModel[T]) autoUpdateNow(field *schema.Field) any {
+	t := r.db.NowFunc()
+	switch field.AutoUpdateTime {
+	case schema.UnixNanosecond:
+		return t.UnixNano()
+	case schema.UnixMillisecond:
+		return t.UnixMilli()
+	case schema.UnixSecond:
+		return t.Unix()
+	}
+	return t
+}
+
+// updatedAtField returns the model's first auto-update timestamp field, or nil if it has none.
+func (r *BaseModel[T]) updatedAtField() *schema.Field {
+	for _, f := range r.schema.Fields {
+		if f.AutoUpdateTime > 0 && f.DBName != "" {
+			return f
+		}
+	}
+	return nil
+}
+
+// UpdateAll updates columns of every record, optionally narrowed by scopes, and returns the
+// number of rows affected. updates can be a map[string]any or a struct, as for UpdateColumns.
+// It is the explicit opt-out of the ErrDangerous guard of UpdateColumn and UpdateColumns.
+func (r *BaseModel[T]) UpdateAll(ctx context.Context, tx *gorm.DB, updates any, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "UpdateAll", time.Now(), &err)
+	res := r.scWithTX(tx, ctx, scopes...).Session(&gorm.Session{AllowGlobalUpdate: true}).Updates(updates)
+	return res.RowsAffected, res.Error
+}
+
+// UpdateColumnsReturningIDs updates multiple columns for records matching the provided
+// scopes and returns the primary key values of the affected rows.
+// At least one scope must be provided to prevent accidental update of all records.
+// On dialects supporting RETURNING the keys are read back from the UPDATE itself;
+// otherwise the matching keys are selected first and the update is restricted to them,
+// both within a single transaction.
+func (r *BaseModel[T]) UpdateColumnsReturningIDs(ctx context.Context, tx *gorm.DB, updates any, scopes ...Scope) (_ []any, err error) {
+	defer r.observe(ctx, "UpdateColumnsReturningIDs", time.Now(), &err)
+	if len(scopes) == 0 {
+		return nil, ErrDangerous
+	}
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return nil, ErrNoPrimaryKey
+	}
+
+	if supportsReturning(r.db.Callback().Update().Clauses) {
+		var rows []T
+		err := r.scWithTX(tx, ctx, scopes...).
+			Model(&rows).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: pk.DBName}}}).
+			Updates(updates).Error
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]any, 0, len(rows))
+		for i := range rows {
+			v, _ := pk.ValueOf(ctx, reflect.ValueOf(&rows[i]).Elem())
+			ids = append(ids, v)
+		}
+		return ids, nil
+	}
+
+	var ids []any
+	run := func(tx *gorm.DB) error {
+		var rows []T
+		if err := r.scWithTX(tx, ctx, scopes...).Select(pk.DBName).Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		keys := make([]any, 0, len(rows))
+		for i := range rows {
+			v, _ := pk.ValueOf(ctx, reflect.ValueOf(&rows[i]).Elem())
+			keys = append(keys, v)
+		}
+		in := Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Values: keys})
+		if err := r.scWithTX(tx, ctx, append(scopes, in)...).Updates(updates).Error; err != nil {
+			return err
+		}
+		ids = keys
+		return nil
+	}
+	if err := r.inTx(ctx, tx, run); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// UpdateColumnsReturning updates multiple columns for records matching the provided scopes
+// and returns the updated records, read back with RETURNING from the UPDATE itself.
+// At least one scope must be provided to prevent accidental update of all records.
+// Returns ErrUnsupported on dialects without RETURNING.
+func (r *BaseModel[T]) UpdateColumnsReturning(ctx context.Context, tx *gorm.DB, updates any, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "UpdateColumnsReturning", time.Now(), &err)
+	if len(scopes) == 0 {
+		return nil, ErrDangerous
+	}
+	if !supportsReturning(r.db.Callback().Update().Clauses) {
+		return nil, fmt.Errorf("%w: %s: RETURNING", ErrUnsupported, r.db.Dialector.Name())
+	}
+	var rows []T
+	err = r.scWithTX(tx, ctx, scopes...).Model(&rows).Clauses(clause.Returning{}).Updates(updates).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// UpdateAndFetch updates multiple columns of the single record matching the provided scopes
+// and returns its new state, e.g. for a PATCH endpoint. Where the dialect supports RETURNING,
+// the record is read back from the UPDATE itself; elsewhere it is updated and re-selected by
+// primary key within one transaction. Returns ErrNotFound if no record matches, and an error
+// wrapping ErrDangerous, with the update rolled back, if more than one does; when tx is
+// provided, rolling it back is left to the caller.
+// At least one scope must be provided to prevent accidental update of all records.
+func (r *BaseModel[T]) UpdateAndFetch(ctx context.Context, tx *gorm.DB, updates any, scopes ...Scope) (_ T, err error) {
+	defer r.observe(ctx, "UpdateAndFetch", time.Now(), &err)
+	var out T
+	if len(scopes) == 0 {
+		return out, ErrDangerous
+	}
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return out, ErrNoPrimaryKey
+	}
+	single := func(n int) error {
+		if n == 0 {
+			return ErrNotFound
+		}
+		if n > 1 {
+			return fmt.Errorf("%w: UpdateAndFetch matched %d records", ErrDangerous, n)
+		}
+		return nil
+	}
+
+	err = r.inTx(ctx, tx, func(tx *gorm.DB) error {
+		if supportsReturning(r.db.Callback().Update().Clauses) {
+			var rows []T
+			err := r.scWithTX(tx, ctx, scopes...).Model(&rows).Clauses(clause.Returning{}).Updates(updates).Error
+			if err != nil {
+				return err
+			}
+			if err := single(len(rows)); err != nil {
+				return err
+			}
+			out = rows[0]
+			return r.decrypt(ctx, &out)
+		}
+
+		var keys []T
+		if err := r.scWithTX(tx, ctx, scopes...).Select(pk.DBName).Limit(2).Find(&keys).Error; err != nil {
+			return err
+		}
+		if err := single(len(keys)); err != nil {
+			return err
+		}
+		id, _ := pk.ValueOf(ctx, reflect.ValueOf(&keys[0]).Elem())
+		byID := Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Value: id})
+		if _, err := r.updateColumns(ctx, tx, updates, append(scopes[:len(scopes):len(scopes)], byID)); err != nil {
+			return err
+		}
+		var err error
+		out, err = r.first(WithTx(ctx, tx), []Scope{byID})
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
+
+// Delete removes records from the database based on the provided conditions.
+// At least one scope must be provided to prevent accidental deletion of all records.
+// If tx is provided, the operation is performed within that transaction.
+// Hooks registered with OnBeforeDelete and OnAfterDelete run around the delete.
+// Foreign key violations are returned as ErrForeignKeyViolation.
+func (r *BaseModel[T]) Delete(ctx context.Context, tx *gorm.DB, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "Delete", time.Now(), &err)
+	if len(scopes) == 0 {
+		return ErrDangerous
+	}
+	_, err = r.delete(ctx, tx, false, scopes)
+	return err
+}
+
+// DeleteAffected is like Delete but also returns the number of rows affected.
+func (r *BaseModel[T]) DeleteAffected(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "DeleteAffected", time.Now(), &err)
+	if len(scopes) == 0 {
+		return 0, ErrDangerous
+	}
+	return r.delete(ctx, tx, false, scopes)
+}
+
+// DeleteByIDs removes the records with the given primary keys in a single DELETE (or, for
+// soft delete, UPDATE) ... WHERE pk IN (...), as Delete does, including its hooks.
+// For composite keys each element of ids is a map or an ordered []any, as accepted by
+// FindByPK, and the records are matched with one (a AND b) group per key, OR-ed together.
+// An empty ids slice is a no-op. Returns ErrNoPrimaryKey if the model has no primary key
+// and ErrInvalidColumn if a key does not match it.
+func (r *BaseModel[T]) DeleteByIDs(ctx context.Context, tx *gorm.DB, ids []any) (err error) {
+	defer r.observe(ctx, "DeleteByIDs", time.Now(), &err)
+	fields := r.schema.PrimaryFields
+	if len(fields) == 0 {
+		return ErrNoPrimaryKey
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var cond clause.Expression
+	if len(fields) == 1 {
+		cond = clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: fields[0].DBName}, Values: ids}
+	} else {
+		keys := make([]clause.Expression, len(ids))
+		for i, id := range ids {
+			conds, err := r.pkConditions(id)
+			if err != nil {
+				return err
+			}
+			keys[i] = clause.And(conds...)
+		}
+		cond = clause.Or(keys...)
+	}
+	_, err = r.delete(ctx, tx, false, []Scope{Where(cond)})
+	return err
+}
+
+// DeleteAll deletes every record, optionally narrowed or modified by scopes (for example
+// WithDeleted to delete permanently), and returns the number of rows affected. It is the
+// explicit opt-out of the ErrDangerous guard of Delete, for cases such as clearing test data.
+// Delete hooks run as for Delete.
+func (r *BaseModel[T]) DeleteAll(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "DeleteAll", time.Now(), &err)
+	return r.delete(ctx, tx, true, scopes)
+}
+
+// delete runs a delete with hooks; all permits deleting without conditions.
+func (r *BaseModel[T]) delete(ctx context.Context, tx *gorm.DB, all bool, scopes []Scope) (int64, error) {
+	var n int64
+	before, after := deleteHooks(r.hooks.beforeDelete, scopes), deleteHooks(r.hooks.afterDelete, scopes)
+	err := r.withHooks(ctx, tx, before, after, func(tx *gorm.DB) error {
+		q := r.scWithTX(tx, ctx, scopes...)
+		if all {
+			q = q.Session(&gorm.Session{AllowGlobalUpdate: true})
+		}
+		res := q.Delete(new(T))
+		n = res.RowsAffected
+		return translateError(res.Error)
+	})
+	return n, err
+}
+
+// DeleteReturning is like Delete but returns the deleted records, read back with RETURNING
+// from the DELETE (or, for soft delete, the UPDATE) itself. Returns ErrUnsupported on
+// dialects without RETURNING. Foreign key violations are returned as ErrForeignKeyViolation.
+func (r *BaseModel[T]) DeleteReturning(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "DeleteReturning", time.Now(), &err)
+	if len(scopes) == 0 {
+		return nil, ErrDangerous
+	}
+	if !supportsReturning(r.db.Callback().Delete().Clauses) {
+		return nil, fmt.Errorf("%w: %s: RETURNING", ErrUnsupported, r.db.Dialector.Name())
+	}
+	var rows []T
+	before, after := deleteHooks(r.hooks.beforeDelete, scopes), deleteHooks(r.hooks.afterDelete, scopes)
+	err = r.withHooks(ctx, tx, before, after, func(tx *gorm.DB) error {
+		return translateError(r.scWithTX(tx, ctx, scopes...).Clauses(clause.Returning{}).Delete(&rows).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// HardDelete permanently removes records matching the provided scopes, bypassing soft delete.
+// For models without a soft-delete field it behaves identically to Delete.
+// At least one scope must be provided to prevent accidental deletion of all records.
+// If tx is provided, the operation is performed within that transaction.
+// Delete hooks run as for Delete, with WithDeleted appended to the scopes they receive.
+// Foreign key violations are returned as ErrForeignKeyViolation.
+func (r *BaseModel[T]) HardDelete(ctx context.Context, tx *gorm.DB, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "HardDelete", time.Now(), &err)
+	if len(scopes) == 0 {
+		return ErrDangerous
+	}
+	_, err = r.delete(ctx, tx, false, append(scopes[:len(scopes):len(scopes)], WithDeleted()))
+	return err
+}
+
+// PurgeDeleted permanently removes records that were soft-deleted before olderThan, such as
+// for data retention, and returns the number of rows removed. The soft-delete column is read
+// from the schema; additional scopes narrow the purge further. If tx is provided, the
+// operation is performed within that transaction. Delete hooks run as for HardDelete.
+// Models without a soft-delete field return ErrUnsupported. Foreign key violations are returned
+// as ErrForeignKeyViolation.
+func (r *BaseModel[T]) PurgeDeleted(ctx context.Context, tx *gorm.DB, olderThan time.Time, scopes ...Scope) (purged int64, err error) {
+	defer r.observe(ctx, "PurgeDeleted", time.Now(), &err)
+	if r.deletedAt == nil {
+		return 0, fmt.Errorf("%w: model %s has no soft-delete field", ErrUnsupported, r.schema.Name)
+	}
+	col := clause.Column{Table: clause.CurrentTable, Name: r.deletedAt.DBName}
+	q := append(scopes[:len(scopes):len(scopes)], Where(clause.Lt{Column: col, Value: olderThan}), WithDeleted())
+	return r.delete(ctx, tx, false, q)
+}
+
+// DeleteCascade soft-deletes the records matching the provided scopes together with their
+// has-one/has-many associations, all within one transaction (tx if provided, otherwise a new one).
+// Parents and children are stamped with the same deleted_at value, which lets RestoreCascade
+// restore exactly the children removed by this call. The model and every association must
+// use gorm.DeletedAt, otherwise ErrUnsupported is returned; only direct associations (no
+// nested paths) are supported.
+// At least one scope must be provided to prevent accidental deletion of all records.
+func (r *BaseModel[T]) DeleteCascade(ctx context.Context, tx *gorm.DB, associations []string, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "DeleteCascade", time.Now(), &err)
+	if len(scopes) == 0 {
+		return ErrDangerous
+	}
+	del, pk, err := r.cascadeFields()
+	if err != nil {
+		return err
+	}
+
+	now := r.db.NowFunc()
+	run := func(tx *gorm.DB) error {
+		parents := r.scWithTX(tx, ctx, scopes...).Select("?", clause.Column{Table: clause.CurrentTable, Name: pk.DBName})
+		for _, name := range associations {
+			child, err := r.cascadeChild(tx, ctx, name, parents)
+			if err != nil {
+				return err
+			}
+			err = child.db.Where(clause.Eq{Column: child.deletedAt, Value: nil}).
+				UpdateColumn(child.deletedAt.Name, now).Error
+			if err != nil {
+				return fmt.Errorf("delete %s: %w", name, err)
+			}
+		}
+		return r.scWithTX(tx, ctx, scopes...).UpdateColumn(del.DBName, now).Error
+	}
+	return r.inTx(ctx, tx, run)
+}
+
+// RestoreCascade restores the soft-deleted records matching the provided scopes together with
+// the children of the given has-one/has-many associations that were deleted at the same time
+// as their parent, as DeleteCascade does. Children deleted independently, at another time,
+// stay deleted. All updates run in one transaction (tx if provided, otherwise a new one).
+// At least one scope must be provided to prevent accidental restoration of all records.
+func (r *BaseModel[T]) RestoreCascade(ctx context.Context, tx *gorm.DB, associations []string, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "RestoreCascade", time.Now(), &err)
+	if len(scopes) == 0 {
+		return ErrDangerous
+	}
+	del, pk, err := r.cascadeFields()
+	if err != nil {
+		return err
+	}
+
+	deleted := Where(clause.Neq{Column: clause.Column{Table: clause.CurrentTable, Name: del.DBName}, Value: nil})
+	run := func(tx *gorm.DB) error {
+		parents := r.scWithTX(tx, ctx, append(scopes, deleted)...).Unscoped().
+			Select("?", clause.Column{Table: clause.CurrentTable, Name: pk.DBName})
+		for _, name := range associations {
+			child, err := r.cascadeChild(tx, ctx, name, parents)
+			if err != nil {
+				return err
+			}
+			// Only children stamped with their own parent's deletion time
+			err = child.db.Where("? = (SELECT ? FROM ? WHERE ? = ?)",
+				child.deletedAt,
+				clause.Column{Table: "p", Name: del.DBName},
+				clause.Table{Name: r.TableName(), Alias: "p"},
+				clause.Column{Table: "p", Name: pk.DBName},
+				child.foreignKey,
+			).UpdateColumn(child.deletedAt.Name, nil).Error
+			if err != nil {
+				return fmt.Errorf("restore %s: %w", name, err)
+			}
+		}
+		return r.scWithTX(tx, ctx, append(scopes, deleted)...).Unscoped().UpdateColumn(del.DBName, nil).Error
+	}
+	return r.inTx(ctx, tx, run)
+}
+
+// cascadeChild describes the child rows of one association during a cascading update.
+type cascadeChild struct {
+	db         *gorm.DB
+	deletedAt  clause.Column
+	foreignKey clause.Column
+}
+
+// cascadeFields returns the soft-delete and primary key fields required for cascading.
+func (r *BaseModel[T]) cascadeFields() (*schema.Field, *schema.Field, error) {
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return nil, nil, ErrNoPrimaryKey
+	}
+	del := r.deletedAt
+	if del == nil {
+		return nil, nil, fmt.Errorf("%w: model %s has no soft-delete field", ErrUnsupported, r.schema.Name)
+	}
+	return del, pk, nil
+}
+
+// cascadeChild resolves the has-one/has-many association name and returns a query over
+// its child rows that reference one of the parent keys selected by parents.
+func (r *BaseModel[T]) cascadeChild(tx *gorm.DB, ctx context.Context, name string, parents *gorm.DB) (cascadeChild, error) {
+	rel, ok := r.schema.Relationships.Relations[name]
+	if !ok || (rel.Type != schema.HasOne && rel.Type != schema.HasMany) {
+		return cascadeChild{}, fmt.Errorf("unsupported association %q: must be has-one or has-many", name)
+	}
+	del := softDeleteField(rel.FieldSchema)
+	if del == nil {
+		return cascadeChild{}, fmt.Errorf("%w: association %q has no soft-delete field", ErrUnsupported, name)
+	}
+
+	table := rel.FieldSchema.Table
+	out := cascadeChild{deletedAt: clause.Column{Table: table, Name: del.DBName}}
+	q := tx.Session(&gorm.Session{NewDB: true}).WithContext(ctx).Table(table)
+	for _, ref := range rel.References {
+		col := clause.Column{Table: table, Name: ref.ForeignKey.DBName}
+		switch {
+		case ref.OwnPrimaryKey && out.foreignKey.Name == "":
+			out.foreignKey = col
+			q = q.Where("? IN (?)", col, parents)
+		case ref.PrimaryValue != "":
+			// Polymorphic type column
+			q = q.Where(clause.Eq{Column: col, Value: ref.PrimaryValue})
+		default:
+			return cascadeChild{}, fmt.Errorf("unsupported association %q: composite keys are not supported", name)
+		}
+	}
+	out.db = q
+	return out, nil
+}
+
+// OrphanCleanup deletes records whose fkColumn references no live row in parentTable,
+// which is needed after parent deletions in soft-delete schemas where no ON DELETE cascade fires.
+// Parent rows count as live when their soft-delete column is NULL. That column is taken from
+// the parent model when T declares a relation to parentTable, and is otherwise the column
+// GORM's naming strategy gives a DeletedAt field (deleted_at), if parentTable has it; parents
+// without one are all live. Records with a NULL foreign key are left untouched.
+// Records are soft-deleted when T supports soft delete, and delete hooks run as for Delete.
+// Returns ErrInvalidColumn if fkColumn is not a column of T, otherwise the number of
+// records deleted.
+func (r *BaseModel[T]) OrphanCleanup(ctx context.Context, tx *gorm.DB, fkColumn, parentTable, parentPK string) (_ int64, err error) {
+	defer r.observe(ctx, "OrphanCleanup", time.Now(), &err)
+	fk := r.schema.LookUpField(fkColumn)
+	if fk == nil || fk.DBName == "" {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidColumn, fkColumn)
+	}
+	db := r.conn(ctx, tx).WithContext(ctx)
+
+	parents := db.Session(&gorm.Session{NewDB: true}).Table(parentTable).Select(parentPK)
+	if column, ok := r.parentDeletedAt(db, parentTable); ok {
+		parents = parents.Where("? IS NULL", clause.Column{Name: column})
+	}
+
+	orphans := Where("? NOT IN (?)", clause.Column{Table: clause.CurrentTable, Name: fk.DBName}, parents)
+	return r.delete(ctx, tx, true, []Scope{orphans})
+}
+
+// parentDeletedAt returns the soft-delete column of parentTable and whether it has one: the
+// column of the parent model when T has a relation to parentTable, otherwise the naming
+// strategy's column for DeletedAt if the table has it.
+func (r *BaseModel[T]) parentDeletedAt(db *gorm.DB, parentTable string) (string, bool) {
+	for _, rel := range r.schema.Relationships.Relations {
+		if rel.FieldSchema != nil && rel.FieldSchema.Table == parentTable {
+			if f := softDeleteField(rel.FieldSchema); f != nil {
+				return f.DBName, true
+			}
+			return "", false
+		}
+	}
+	column := db.NamingStrategy.ColumnName(parentTable, "DeletedAt")
+	return column, db.Migrator().HasColumn(parentTable, column)
+}
+
+// BatchInsert performs a batch insert operation for multiple entities.
+// If tx is provided, the operation is performed within that transaction.
+// The optional batchSize parameter controls how many records are inserted in each batch.
+// If not specified or zero, defaults to 1000 records per batch.
+// The context is checked between batches, so a cancelled context stops the insert before
+// the next batch. Batches run in one transaction unless the DB skips default transactions,
+// so partial work is rolled back; with SkipDefaultTransaction (and outside tx), batches
+// inserted before the cancellation stay committed.
+func (r *BaseModel[T]) BatchInsert(ctx context.Context, tx *gorm.DB, ents []*T, batchSize ...int) (err error) {
+	defer r.observe(ctx, "BatchInsert", time.Now(), &err)
+	_, err = r.createInBatches(ctx, tx, ents, batchSizeOf(batchSize), nil)
+	return err
+}
+
+// BatchInsertAffected is like BatchInsert but returns the number of rows actually inserted,
+// summed across batches, and accepts an optional conflict policy. With
+// &clause.OnConflict{DoNothing: true}, rows conflicting with existing ones are skipped
+// instead of failing the batch, and are not counted, so an importer can report
+// "inserted 900 of 1000". A nil onConflict inserts exactly as BatchInsert does.
+func (r *BaseModel[T]) BatchInsertAffected(ctx context.Context, tx *gorm.DB, ents []*T, onConflict *clause.OnConflict, batchSize ...int) (_ int64, err error) {
+	defer r.observe(ctx, "BatchInsertAffected", time.Now(), &err)
+	var extra clause.Expression
+	if onConflict != nil {
+		extra = *onConflict
+	}
+	return r.createInBatches(ctx, tx, ents, batchSizeOf(batchSize), extra)
+}
+
+// BatchUpsert inserts entities in batches like BatchInsert, updating updateColumns of the
+// existing row instead when a row conflicts on conflictColumns (which need a unique index).
+// With no updateColumns, conflicting rows are left unchanged. Primary keys of inserted and
+// updated rows are populated where the driver supports RETURNING (PostgreSQL, SQLite).
+func (r *BaseModel[T]) BatchUpsert(ctx context.Context, tx *gorm.DB, ents []*T, conflictColumns, updateColumns []string, batchSize ...int) (err error) {
+	defer r.observe(ctx, "BatchUpsert", time.Now(), &err)
+	if len(conflictColumns) == 0 {
+		return fmt.Errorf("%w: no conflict columns", ErrInvalidColumn)
+	}
+	onConflict := clause.OnConflict{DoNothing: len(updateColumns) == 0}
+	for _, c := range conflictColumns {
+		f := r.schema.LookUpField(c)
+		if f == nil || f.DBName == "" {
+			return fmt.Errorf("%w: %s", ErrInvalidColumn, c)
+		}
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: f.DBName})
+	}
+	updates := make([]string, len(updateColumns))
+	for i, c := range updateColumns {
+		f := r.schema.LookUpField(c)
+		if f == nil || f.DBName == "" {
+			return fmt.Errorf("%w: %s", ErrInvalidColumn, c)
+		}
+		updates[i] = f.DBName
+	}
+	if len(updates) > 0 {
+		onConflict.DoUpdates = clause.AssignmentColumns(updates)
+	}
+	_, err = r.createInBatches(ctx, tx, ents, batchSizeOf(batchSize), onConflict)
+	return err
+}
+
+// createInBatches inserts ents in batches of size, adding the optional extra clause to every
+// INSERT, and returns the number of rows inserted. See BatchInsert for the transaction and
+// cancellation semantics.
+func (r *BaseModel[T]) createInBatches(ctx context.Context, tx *gorm.DB, ents []*T, size int, extra clause.Expression) (int64, error) {
+	if len(ents) == 0 {
+		return 0, nil
+	}
+	db := r.conn(ctx, tx).WithContext(ctx)
+
+	for _, ent := range ents {
+		restore, err := r.encrypt(ctx, ent)
+		if err != nil {
+			return 0, err
+		}
+		defer restore()
+	}
+
+	var n int64
+	run := func(tx *gorm.DB) error {
+		n = 0
+		for i := 0; i < len(ents); i += size {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			end := i + size
+			if end > len(ents) {
+				end = len(ents)
+			}
+			q := r.withTable(tx)
+			if extra != nil {
+				q = q.Clauses(extra)
+			}
+			res := q.Create(ents[i:end])
+			if res.Error != nil {
+				return res.Error
+			}
+			n += res.RowsAffected
+		}
+		return nil
+	}
+	if db.SkipDefaultTransaction || len(ents) <= size {
+		// Without a transaction, batches inserted before an error stay committed.
+		err := run(db)
+		return n, err
+	}
+	if err := db.Transaction(run); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// BatchUpdate writes the given columns of each entity to its row, keyed by primary key, so
+// that every row receives its own values. Rows are updated in batches (default 1000) with one
+// UPDATE ... SET column = CASE pk WHEN ... END statement per batch, all in one transaction
+// when there is more than one batch, unless the DB skips default transactions.
+// Only the named columns and the auto-update timestamp, set from the DB's NowFunc, are
+// written: hooks are not run and soft-deleted rows are not updated. The context is checked
+// between batches.
+func (r *BaseModel[T]) BatchUpdate(ctx context.Context, tx *gorm.DB, ents []*T, columns []string, batchSize ...int) (err error) {
+	defer r.observe(ctx, "BatchUpdate", time.Now(), &err)
+	if len(ents) == 0 {
+		return nil
+	}
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return ErrNoPrimaryKey
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: no columns to update", ErrInvalidColumn)
+	}
+	fields := make([]*schema.Field, len(columns))
+	for i, c := range columns {
+		f := r.schema.LookUpField(c)
+		if f == nil || f.DBName == "" || f.PrimaryKey {
+			return fmt.Errorf("%w: %s", ErrInvalidColumn, c)
+		}
+		fields[i] = f
+	}
+	db := r.conn(ctx, tx).WithContext(ctx)
+
+	size := batchSizeOf(batchSize)
+	for _, ent := range ents {
+		restore, err := r.encrypt(ctx, ent)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	// UpdateColumns skips automatic timestamps, so the update time is set explicitly
+	touched := r.updatedAtField()
+
+	// PostgreSQL types CASE results from untyped parameters as text, so cast them back
+	cast := db.Dialector.Name() == "postgres"
+	pkCol := clause.Column{Name: pk.DBName}
+	run := func(tx *gorm.DB) error {
+		for i := 0; i < len(ents); i += size {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			end := i + size
+			if end > len(ents) {
+				end = len(ents)
+			}
+
+			ids := make([]any, 0, end-i)
+			for j, ent := range ents[i:end] {
+				id, zero := pk.ValueOf(ctx, reflect.ValueOf(ent).Elem())
+				if zero {
+					return fmt.Errorf("%w: entity %d", ErrNoPrimaryKey, i+j)
+				}
+				ids = append(ids, id)
+			}
+
+			updates := make(map[string]any, len(fields))
+			for _, f := range fields {
+				var sql strings.Builder
+				vars := make([]any, 0, 2*len(ids)+2)
+				sql.WriteString("CASE ?")
+				vars = append(vars, pkCol)
+				for j, ent := range ents[i:end] {
+					v, _ := f.ValueOf(ctx, reflect.ValueOf(ent).Elem())
+					if cast {
+						sql.WriteString(" WHEN ? THEN CAST(? AS " + db.Dialector.DataTypeOf(f) + ")")
+					} else {
+						sql.WriteString(" WHEN ? THEN ?")
+					}
+					vars = append(vars, ids[j], v)
+				}
+				sql.WriteString(" ELSE ? END")
+				vars = append(vars, clause.Column{Name: f.DBName})
+				updates[f.DBName] = gorm.Expr(sql.String(), vars...)
+			}
+			if touched != nil {
+				if _, ok := updates[touched.DBName]; !ok {
+					updates[touched.DBName] = r.autoUpdateNow(touched)
+				}
+			}
+
+			in := Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Values: ids})
+			if err := r.scWithTX(tx, ctx, in).UpdateColumns(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if db.SkipDefaultTransaction || len(ents) <= size {
+		return run(db)
+	}
+	return db.Transaction(run)
+}
+
+// InsertRows returns the columns and per-entity values that inserting ents would write, for
+// bulk loaders that bypass GORM's create callbacks, such as pgcopy.CopyInsert.
+// Columns are mapped from the parsed schema; auto-increment primary keys are left to the
+// database. Zero auto-create/update timestamps are set on the entities from the DB's NowFunc
+// and encrypted columns (see WithEncryptColumn) hold their ciphertext. A bulk load cannot skip
+// a column for some rows only, so zero values of fields with a literal default tag (default:0,
+// default:'new') are returned as that default, while fields with an expression default
+// (default:now()) are left out only when they are zero in every entity, and are returned
+// as given otherwise.
+func (r *BaseModel[T]) InsertRows(ctx context.Context, ents []*T) (columns []string, rows [][]any, err error) {
+	defer r.observe(ctx, "InsertRows", time.Now(), &err)
+	var fields []*schema.Field
+	for _, f := range r.schema.Fields {
+		if f.DBName == "" || !f.Creatable || (f.PrimaryKey && f.AutoIncrement) {
+			continue
+		}
+		if f.HasDefaultValue && f.DefaultValueInterface == nil && f.DefaultValue != "" && allZero(ctx, f, ents) {
+			continue
+		}
+		fields = append(fields, f)
+		columns = append(columns, f.DBName)
+	}
+
+	now := r.db.NowFunc()
+	rows = make([][]any, len(ents))
+	for i, ent := range ents {
+		rv := reflect.ValueOf(ent).Elem()
+		for _, f := range fields {
+			if _, zero := f.ValueOf(ctx, rv); zero && (f.AutoCreateTime > 0 || f.AutoUpdateTime > 0) {
+				if err := f.Set(ctx, rv, now); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		restore, err := r.encrypt(ctx, ent)
+		if err != nil {
+			return nil, nil, err
+		}
+		row := make([]any, len(fields))
+		for j, f := range fields {
+			v, zero := f.ValueOf(ctx, rv)
+			if zero && f.DefaultValueInterface != nil {
+				v = f.DefaultValueInterface
+			}
+			row[j] = v
+		}
+		restore()
+		rows[i] = row
+	}
+	return columns, rows, nil
+}
+
+// allZero reports whether f holds its zero value in every entity of ents.
+func allZero[T any](ctx context.Context, f *schema.Field, ents []*T) bool {
+	for _, ent := range ents {
+		if _, zero := f.ValueOf(ctx, reflect.ValueOf(ent).Elem()); !zero {
+			return false
+		}
+	}
+	return true
+}
+
+// First retrieves the first record that matches the provided scopes.
+// Returns ErrNotFound if no record is found.
+func (r *BaseModel[T]) First(ctx context.Context, scopes ...Scope) (_ T, err error) {
+	defer r.observe(ctx, "First", time.Now(), &err)
+	return r.first(ctx, scopes)
+}
+
+// first implements First.
+func (r *BaseModel[T]) first(ctx context.Context, scopes []Scope) (T, error) {
+	var out T
+	if err := r.sc(ctx, scopes...).First(&out).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, ErrNotFound
+		}
+		return out, err
+	}
+	if err := r.decrypt(ctx, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// FindByPK retrieves the record with the given primary key, reading the key columns from the
+// schema, so models whose key is not named ID or spans several columns are supported.
+// key is a single value for a single-column key, or for composite keys either a map of
+// column (or field) names to values, such as map[string]any{"user_id": 1, "role_id": 2},
+// or a []any of values in the order the key fields are declared. Additional scopes, such
+// as WithDeleted or Preload, are applied as in First.
+// Returns ErrNotFound if no record is found and ErrInvalidColumn if key does not match
+// the primary key.
+func (r *BaseModel[T]) FindByPK(ctx context.Context, key any, scopes ...Scope) (_ T, err error) {
+	defer r.observe(ctx, "FindByPK", time.Now(), &err)
+	var zero T
+	conds, err := r.pkConditions(key)
+	if err != nil {
+		return zero, err
+	}
+	return r.first(ctx, append(scopes, Where(clause.And(conds...))))
+}
+
+// FindByIDsOrdered retrieves the records with the given primary keys in a single IN query
+// and returns them in the order of ids, as a batch loader (e.g. a GraphQL dataloader) needs.
+// IDs without a matching record are dropped, and an ID listed twice yields its record twice.
+// IDs are matched by their formatted value, so an int ID finds a record with a uint key.
+// An empty ids slice returns no records. Returns ErrNoPrimaryKey if the model has no primary
+// key, or only a composite one.
+func (r *BaseModel[T]) FindByIDsOrdered(ctx context.Context, ids []any, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "FindByIDsOrdered", time.Now(), &err)
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return nil, ErrNoPrimaryKey
+	}
+	if len(ids) == 0 {
+		return []T{}, nil
+	}
+
+	col := clause.Column{Table: clause.CurrentTable, Name: pk.DBName}
+	found, err := r.list(ctx, append(scopes[:len(scopes):len(scopes)], Where(clause.IN{Column: col, Values: ids})))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]int, len(found))
+	for i := range found {
+		id, _ := pk.ValueOf(ctx, reflect.ValueOf(&found[i]).Elem())
+		byID[fmt.Sprint(id)] = i
+	}
+
+	out := make([]T, 0, len(ids))
+	for _, id := range ids {
+		if i, ok := byID[fmt.Sprint(id)]; ok {
+			out = append(out, found[i])
+		}
+	}
+	return out, nil
+}
+
+// Refresh reloads ent in place from the database, selecting the row by the primary key
+// values read from ent through the schema. The read goes to the primary, so changes just
+// committed elsewhere are visible despite replica lag.
+// Returns ErrNotFound, leaving ent unchanged, if the row no longer exists or is soft-deleted.
+func (r *BaseModel[T]) Refresh(ctx context.Context, ent *T) (err error) {
+	defer r.observe(ctx, "Refresh", time.Now(), &err)
+	rv := reflect.ValueOf(ent).Elem()
+	key := make([]any, len(r.schema.PrimaryFields))
+	for i, f := range r.schema.PrimaryFields {
+		key[i], _ = f.ValueOf(ctx, rv)
+	}
+	conds, err := r.pkConditions(key)
+	if err != nil {
+		return err
+	}
+	out, err := r.first(UsePrimary(ctx), []Scope{Where(clause.And(conds...))})
+	if err != nil {
+		return err
+	}
+	*ent = out
+	return nil
+}
+
+// pkConditions returns an equality condition per primary key column for key, given as
+// described for FindByPK.
+func (r *BaseModel[T]) pkConditions(key any) ([]clause.Expression, error) {
+	fields := r.schema.PrimaryFields
+	if len(fields) == 0 {
+		return nil, ErrNoPrimaryKey
+	}
+
+	var values []any
+	switch k := key.(type) {
+	case map[string]any:
+		values = make([]any, len(fields))
+		seen := make([]bool, len(fields))
+		for name, v := range k {
+			i := -1
+			if f := r.schema.LookUpField(name); f != nil {
+				for j, pf := range fields {
+					if pf == f {
+						i = j
+					}
+				}
+			}
+			if i < 0 || seen[i] {
+				return nil, fmt.Errorf("%w: %s is not a primary key column", ErrInvalidColumn, name)
+			}
+			values[i], seen[i] = v, true
+		}
+		for i, ok := range seen {
+			if !ok {
+				return nil, fmt.Errorf("%w: missing primary key column %s", ErrInvalidColumn, fields[i].DBName)
+			}
+		}
+	case []any:
+		values = k
+	default:
+		values = []any{key}
+	}
+	if len(values) != len(fields) {
+		return nil, fmt.Errorf("%w: primary key has %d columns, got %d values", ErrInvalidColumn, len(fields), len(values))
+	}
+
+	conds := make([]clause.Expression, len(fields))
+	for i, f := range fields {
+		conds[i] = clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: values[i]}
+	}
+	return conds, nil
+}
+
+// Last retrieves the last record by primary key that matches the provided scopes,
+// such as the most recently created one.
+// Returns ErrNotFound if no record is found.
+func (r *BaseModel[T]) Last(ctx context.Context, scopes ...Scope) (_ T, err error) {
+	defer r.observe(ctx, "Last", time.Now(), &err)
+	var out T
+	if err := r.sc(ctx, scopes...).Last(&out).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, ErrNotFound
+		}
+		return out, err
+	}
+	if err := r.decrypt(ctx, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// FirstOrZero is like First but reports absence through found instead of an error:
+// when no record matches it returns the zero T, found=false and a nil error.
+// A non-nil error is returned only for actual database failures.
+func (r *BaseModel[T]) FirstOrZero(ctx context.Context, scopes ...Scope) (out T, found bool, err error) {
+	defer r.observe(ctx, "FirstOrZero", time.Now(), &err)
+	out, err = r.first(ctx, scopes)
+	if errors.Is(err, ErrNotFound) {
+		return out, false, nil
+	}
+	if err != nil {
+		return out, false, err
+	}
+	return out, true, nil
+}
+
+// List retrieves all records that match the provided scopes.
+// Consider using Limit and Order scopes to control the result set size and ordering.
+func (r *BaseModel[T]) List(ctx context.Context, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "List", time.Now(), &err)
+	return r.list(ctx, scopes)
+}
+
+// list implements List.
+func (r *BaseModel[T]) list(ctx context.Context, scopes []Scope) ([]T, error) {
+	var out []T
+	if err := r.sc(ctx, scopes...).Find(&out).Error; err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FirstOrInit retrieves the first record that matches the provided scopes like First, but
+// when none exists it returns, with found=false and a nil error, an unsaved T initialized
+// from the non-zero fields of attrs and the equality conditions of the scopes, wrapping
+// GORM's FirstOrInit. Only conditions given as maps, structs or clause.Eq (such as WhereEq)
+// initialize fields; raw SQL strings like Where("email = ?", ...) only filter. Nothing is
+// written to the database, which suits pre-populating forms. attrs is not applied to a
+// found record.
+func (r *BaseModel[T]) FirstOrInit(ctx context.Context, attrs T, scopes ...Scope) (ent T, found bool, err error) {
+	defer r.observe(ctx, "FirstOrInit", time.Now(), &err)
+	var out T
+	res := r.sc(ctx, scopes...).Attrs(&attrs).FirstOrInit(&out)
+	if res.Error != nil {
+		return ent, false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return out, false, nil
+	}
+	if err := r.decrypt(ctx, &out); err != nil {
+		return ent, false, err
+	}
+	return out, true, nil
+}
+
+// ListWithCount retrieves the records matching the provided scopes together with their
+// count, both read from one consistent snapshot: a read-only REPEATABLE READ transaction,
+// so concurrent writes cannot make the total disagree with the items. Within a transaction
+// carried by ctx both queries run in that transaction, with its isolation level.
+// The scopes are applied to both queries, so pass filters only; for a page of items with
+// the total of all matches, use Page.
+func (r *BaseModel[T]) ListWithCount(ctx context.Context, scopes ...Scope) (items []T, total int64, err error) {
+	defer r.observe(ctx, "ListWithCount", time.Now(), &err)
+	run := func(tx *gorm.DB) error {
+		ctx := WithTx(ctx, tx)
+		var err error
+		if total, err = r.count(ctx, scopes); err != nil {
+			return err
+		}
+		items, err = r.list(ctx, scopes)
+		return err
+	}
+	if tx := txFromContext(ctx); tx != nil {
+		err = run(tx)
+	} else {
+		err = r.readConn(ctx).WithContext(ctx).Transaction(run, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// FindInBatches streams the records matching the provided scopes in batches of batchSize
+// (default 1000), calling fn for each batch, so large result sets are never held in memory
+// at once. Batches are fetched by primary key ranges, as GORM's FindInBatches does.
+// Iteration stops at the first error returned by fn, which is propagated, or once the context
+// is cancelled, in which case the context error is returned and no further batches are passed
+// to fn. The slice passed to fn is reused between batches and must not be retained.
+func (r *BaseModel[T]) FindInBatches(ctx context.Context, batchSize int, fn func(batch []T) error, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "FindInBatches", time.Now(), &err)
+	var batch []T
+	q := r.sc(ctx, scopes...)
+	// Batches run on clones of q, which do not release a WithTimeout scope, so release it here
+	defer cancelTimeout(q)
+	return q.FindInBatches(&batch, batchSizeOf([]int{batchSize}), func(tx *gorm.DB, _ int) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := r.decryptAll(ctx, batch); err != nil {
+			return err
+		}
+		return fn(batch)
+	}).Error
+}
+
+// Pluck retrieves the values of a single column for records matching the provided scopes.
+// Scopes such as Order and Limit are honored, so "top N ids" queries work as expected.
+// Columns encrypted with WithEncryptColumn are returned as stored, not decrypted.
+// The value type comes first so that T can be inferred from the base model:
+//
+//	ids, err := gormplus.Pluck[uint](ctx, userBaseModel, "id", gormplus.Limit(100))
+func Pluck[V, T any](ctx context.Context, r *BaseModel[T], column string, scopes ...Scope) (_ []V, err error) {
+	defer r.observe(ctx, "Pluck", time.Now(), &err)
+	var out []V
+	if err := r.sc(ctx, scopes...).Pluck(column, &out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MapBy lists the records matching the provided scopes, like List, and indexes them by the
+// key keyFn returns for each. When several records share a key, the last one listed wins,
+// so combine it with an Order scope if that matters. Both type parameters are inferred:
+//
+//	byID, err := gormplus.MapBy(ctx, userBaseModel, func(u User) uint { return u.ID })
+func MapBy[K comparable, T any](ctx context.Context, r *BaseModel[T], keyFn func(T) K, scopes ...Scope) (_ map[K]T, err error) {
+	defer r.observe(ctx, "MapBy", time.Now(), &err)
+	items, err := r.list(ctx, scopes)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[K]T, len(items))
+	for _, item := range items {
+		out[keyFn(item)] = item
+	}
+	return out, nil
+}
+
+// PluckDistinct is like Pluck but returns each value of column once, selecting it with
+// DISTINCT, e.g. the set of statuses in use. It returns an empty slice when no records match.
+func PluckDistinct[V, T any](ctx context.Context, r *BaseModel[T], column string, scopes ...Scope) (_ []V, err error) {
+	defer r.observe(ctx, "PluckDistinct", time.Now(), &err)
+	out := []V{}
+	if err := r.sc(ctx, scopes...).Distinct().Pluck(column, &out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// existingKeysChunk bounds the IN list of each ExistingKeys query, staying below the bind
+// parameter limits of all supported databases.
+const existingKeysChunk = 500
+
+// ExistingKeys reports which of values are present in column among records matching the
+// provided scopes, e.g. which candidate emails are already registered. Every value is a key
+// of the returned map, true if a matching record exists. Large value sets are queried in
+// chunks to stay under the databases' bind parameter limits. Soft-deleted records are only
+// considered with WithDeleted. The value type comes first so that T can be inferred:
+//
+//	taken, err := gormplus.ExistingKeys[string](ctx, userBaseModel, "email", emails)
+func ExistingKeys[V comparable, T any](ctx context.Context, r *BaseModel[T], column string, values []V, scopes ...Scope) (_ map[V]bool, err error) {
+	defer r.observe(ctx, "ExistingKeys", time.Now(), &err)
+	field := r.schema.LookUpField(column)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidColumn, column)
+	}
+	col := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+
+	out := make(map[V]bool, len(values))
+	for _, v := range values {
+		out[v] = false
+	}
+	for i := 0; i < len(values); i += existingKeysChunk {
+		end := i + existingKeysChunk
+		if end > len(values) {
+			end = len(values)
+		}
+		in := make([]any, end-i)
+		for j, v := range values[i:end] {
+			in[j] = v
+		}
+		q := append(scopes[:len(scopes):len(scopes)], Where(clause.IN{Column: col, Values: in}))
+		var found []V
+		if err := r.sc(ctx, q...).Distinct().Pluck(field.DBName, &found).Error; err != nil {
+			return nil, err
+		}
+		for _, v := range found {
+			out[v] = true
+		}
+	}
+	return out, nil
+}
+
+// ScanInto applies the provided scopes to a query on the model's table and scans the
+// result into dst, which may be a pointer to any struct, slice or scalar. It is meant for
+// projections and aggregates that do not map to T, typically combined with Select,
+// GroupBy and Having. Columns encrypted with WithEncryptColumn are scanned as stored,
+// not decrypted.
+func (r *BaseModel[T]) ScanInto(ctx context.Context, dst any, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "ScanInto", time.Now(), &err)
+	return r.sc(ctx, scopes...).Scan(dst).Error
+}
+
+// Scan is a typed variant of ScanInto that returns the scanned rows as []R.
+// The row type comes first so that T can be inferred from the base model:
+//
+//	stats, err := gormplus.Scan[AgeStat](ctx, userBaseModel, gormplus.Select("age, COUNT(*) AS n"), gormplus.GroupBy("age"))
+func Scan[R, T any](ctx context.Context, r *BaseModel[T], scopes ...Scope) (_ []R, err error) {
+	defer r.observe(ctx, "Scan", time.Now(), &err)
+	var out []R
+	if err := r.sc(ctx, scopes...).Scan(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Exec runs a hand-written SQL statement, such as an UPDATE the query builder cannot express,
+// and returns the number of rows affected. If tx is provided, the statement runs within it.
+// Arguments are bound as with GORM's Exec; never interpolate user input into sql.
+func (r *BaseModel[T]) Exec(ctx context.Context, tx *gorm.DB, sql string, args ...any) (_ int64, err error) {
+	defer r.observe(ctx, "Exec", time.Now(), &err)
+	res := r.conn(ctx, tx).WithContext(ctx).Exec(sql, args...)
+	return res.RowsAffected, res.Error
+}
+
+// RawQuery runs a hand-written SQL query and scans the result rows into []T.
+// Columns are matched to fields by name, as in GORM's Raw(...).Scan.
+// Arguments are bound as with GORM's Raw; never interpolate user input into sql.
+func (r *BaseModel[T]) RawQuery(ctx context.Context, sql string, args ...any) (_ []T, err error) {
+	defer r.observe(ctx, "RawQuery", time.Now(), &err)
+	var out []T
+	if err := r.conn(ctx, nil).WithContext(ctx).Raw(sql, args...).Scan(&out).Error; err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LoadAssociation populates the named association on already-loaded parents using a single
+// query, the same way Preload would had the parents been fetched with it. This avoids N+1
+// queries when the parents come from elsewhere (a cache, another query, a request body).
+// Scopes are applied to the association query; nested associations such as "Orders.Items"
+// are supported.
+func (r *BaseModel[T]) LoadAssociation(ctx context.Context, parents []T, assoc string, scopes ...Scope) (err error) {
+	defer r.observe(ctx, "LoadAssociation", time.Now(), &err)
+	if len(parents) == 0 {
+		return nil
+	}
+	preload := r.db.Callback().Query().Get("gorm:preload")
+	if preload == nil {
+		return errors.New("gorm:preload callback is not registered")
+	}
+
+	conds := make([]any, 0, len(scopes))
+	for _, s := range scopes {
+		if s != nil {
+			conds = append(conds, (func(*gorm.DB) *gorm.DB)(s))
+		}
+	}
+
+	tx := r.conn(ctx, nil).WithContext(ctx).Preload(assoc, conds...)
+	if err := tx.Statement.Parse(new(T)); err != nil {
+		return err
+	}
+	tx.Statement.Dest = &parents
+	tx.Statement.ReflectValue = reflect.ValueOf(parents)
+	preload(tx)
+	return tx.Error
+}
+
+// Union combines the results of several queries with UNION, removing duplicate rows,
+// and scans them into []T. Each query may target different conditions or tables but must
+// select the same column shape, e.g. db.Model(&User{}).Where("age < ?", 18).
+// The combined result is exposed under the model's table name, quoted and without any schema
+// prefix, so scopes such as Where, Order and Limit apply to the union as a whole.
+// Queries are embedded as-is; soft-delete
+// filtering is applied per query by GORM when they are built from a model, not to the union.
+// Some databases (such as SQLite) reject ORDER BY or LIMIT inside the individual queries.
+func (r *BaseModel[T]) Union(ctx context.Context, queries []*gorm.DB, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "Union", time.Now(), &err)
+	return r.union(ctx, "UNION", queries, scopes...)
+}
+
+// UnionAll is like Union but uses UNION ALL, keeping duplicate rows.
+func (r *BaseModel[T]) UnionAll(ctx context.Context, queries []*gorm.DB, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "UnionAll", time.Now(), &err)
+	return r.union(ctx, "UNION ALL", queries, scopes...)
+}
+
+func (r *BaseModel[T]) union(ctx context.Context, op string, queries []*gorm.DB, scopes ...Scope) ([]T, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	parts := make([]string, len(queries))
+	vars := make([]any, len(queries))
+	for i, q := range queries {
+		parts[i] = "?"
+		vars[i] = q
+	}
+	combined := gorm.Expr(strings.Join(parts, " "+op+" "), vars...)
+
+	// An alias cannot be schema-qualified, so only the table's own name is used
+	alias := r.TableName()
+	if i := strings.LastIndexByte(alias, '.'); i >= 0 {
+		alias = alias[i+1:]
+	}
+	db := r.readConn(ctx).WithContext(ctx).Unscoped().Table("(?) AS ?", combined, clause.Table{Name: alias})
+	db.Statement.Table = alias
+	for _, s := range scopes {
+		if s != nil {
+			db = s(db)
+		}
+	}
+
+	var out []T
+	if err := db.Find(&out).Error; err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Count returns the number of records that match the provided scopes.
+// When the scopes join other tables, joined rows would multiply each record, so it counts
+// COUNT(DISTINCT primary key) instead, or with GroupBy the number of groups, ignoring any
+// Order, Limit and Offset; this keeps Page totals right for joined queries.
+// Models with a composite primary key, and scopes that Select or Distinct by columns, are
+// counted as given.
+func (r *BaseModel[T]) Count(ctx context.Context, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "Count", time.Now(), &err)
+	return r.count(ctx, scopes)
+}
+
+// count implements Count.
+func (r *BaseModel[T]) count(ctx context.Context, scopes []Scope) (int64, error) {
+	var total int64
+	db := r.sc(ctx, scopes...)
+	stmt := db.Statement
+	plain := len(stmt.Selects) == 0 || (stmt.Distinct && len(stmt.Selects) == 1 && stmt.Selects[0] == distinctRow)
+	if pk := r.schema.PrioritizedPrimaryField; pk != nil && len(stmt.Joins) > 0 && plain {
+		delete(stmt.Clauses, "ORDER BY")
+		delete(stmt.Clauses, "LIMIT")
+		if _, grouped := stmt.Clauses["GROUP BY"]; grouped {
+			// One row per group, whatever the join multiplied within it
+			stmt.Distinct = false
+			sub := db.Select("1")
+			err := r.readConn(ctx).WithContext(ctx).Raw("SELECT COUNT(*) FROM (?) AS counted", sub).Scan(&total).Error
+			if err != nil {
+				return 0, err
+			}
+			return total, nil
+		}
+		col := clause.Column{Table: clause.CurrentTable, Name: pk.DBName}
+		if err := db.Select("COUNT(DISTINCT ?)", col).Scan(&total).Error; err != nil {
+			return 0, err
+		}
+		return total, nil
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// CountDeleted returns the number of soft-deleted records matching the provided scopes,
+// such as for a trash bin view. Models without a soft-delete field have none and return 0.
+func (r *BaseModel[T]) CountDeleted(ctx context.Context, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "CountDeleted", time.Now(), &err)
+	if r.deletedAt == nil {
+		return 0, nil
+	}
+	return r.count(ctx, append(scopes, r.OnlyDeleted()))
+}
+
+// CountDistinct returns the number of distinct non-NULL values of column among records
+// that match the provided scopes.
+func (r *BaseModel[T]) CountDistinct(ctx context.Context, column string, scopes ...Scope) (_ int64, err error) {
+	defer r.observe(ctx, "CountDistinct", time.Now(), &err)
+	var total int64
+	err = r.sc(ctx, scopes...).Select("COUNT(DISTINCT ?)", clause.Column{Name: column}).Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// GroupCount counts the records matching the provided scopes per distinct value of column,
+// as SELECT column, COUNT(*) ... GROUP BY column, returning the counts keyed by the value's
+// string form (e.g. users per status). Records where column is NULL are counted under "".
+// Returns ErrInvalidColumn if column is not a column of the model.
+func (r *BaseModel[T]) GroupCount(ctx context.Context, column string, scopes ...Scope) (_ map[string]int64, err error) {
+	defer r.observe(ctx, "GroupCount", time.Now(), &err)
+	field := r.schema.LookUpField(column)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidColumn, column)
+	}
+	col := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+
+	var rows []struct {
+		K sql.NullString
+		N int64
+	}
+	err = r.sc(ctx, scopes...).
+		Select("? AS k, COUNT(*) AS n", col).
+		Clauses(clause.GroupBy{Columns: []clause.Column{col}}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		out[row.K.String] += row.N
+	}
+	return out, nil
+}
+
+// Exists checks whether any record matching the provided scopes exists.
+// Returns true if at least one record exists, false otherwise.
+// It issues SELECT 1 ... LIMIT 1, so the database can stop at the first match.
+func (r *BaseModel[T]) Exists(ctx context.Context, scopes ...Scope) (_ bool, err error) {
+	defer r.observe(ctx, "Exists", time.Now(), &err)
+	return r.exists(ctx, scopes)
+}
+
+// exists implements Exists.
+func (r *BaseModel[T]) exists(ctx context.Context, scopes []Scope) (bool, error) {
+	var found []int
+	err := r.sc(ctx, scopes...).Select("1").Limit(1).Scan(&found).Error
+	if err != nil {
+		return false, err
+	}
+	return len(found) > 0, nil
+}
+
+// Sum returns the sum of a numeric column over records matching the provided scopes.
+// Returns 0 when no records match.
+func (r *BaseModel[T]) Sum(ctx context.Context, column string, scopes ...Scope) (_ float64, err error) {
+	defer r.observe(ctx, "Sum", time.Now(), &err)
+	v, err := r.aggregateNull(ctx, AggregateSum, column, scopes)
+	return v.Float64, err
+}
+
+// Avg returns the average of a numeric column over records matching the provided scopes.
+// Returns 0 when no records match.
+func (r *BaseModel[T]) Avg(ctx context.Context, column string, scopes ...Scope) (_ float64, err error) {
+	defer r.observe(ctx, "Avg", time.Now(), &err)
+	v, err := r.aggregateNull(ctx, AggregateAvg, column, scopes)
+	return v.Float64, err
+}
+
+// Min returns the minimum of a numeric column over records matching the provided scopes.
+// Returns 0 when no records match.
+func (r *BaseModel[T]) Min(ctx context.Context, column string, scopes ...Scope) (_ float64, err error) {
+	defer r.observe(ctx, "Min", time.Now(), &err)
+	v, err := r.aggregateNull(ctx, AggregateMin, column, scopes)
+	return v.Float64, err
+}
+
+// Max returns the maximum of a numeric column over records matching the provided scopes.
+// Returns 0 when no records match.
+func (r *BaseModel[T]) Max(ctx context.Context, column string, scopes ...Scope) (_ float64, err error) {
+	defer r.observe(ctx, "Max", time.Now(), &err)
+	v, err := r.aggregateNull(ctx, AggregateMax, column, scopes)
+	return v.Float64, err
+}
+
+// AggregateNull applies an aggregate function to a numeric column over records matching
+// the provided scopes. The result is invalid (Valid == false) when no non-NULL values
+// were aggregated, which distinguishes "no rows" from an aggregate that is zero.
+func (r *BaseModel[T]) AggregateNull(ctx context.Context, fn AggregateFunc, column string, scopes ...Scope) (_ sql.NullFloat64, err error) {
+	defer r.observe(ctx, "AggregateNull", time.Now(), &err)
+	return r.aggregateNull(ctx, fn, column, scopes)
+}
+
+// aggregateNull implements AggregateNull.
+func (r *BaseModel[T]) aggregateNull(ctx context.Context, fn AggregateFunc, column string, scopes []Scope) (sql.NullFloat64, error) {
+	var out sql.NullFloat64
+	switch fn {
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax:
+	default:
+		return out, fmt.Errorf("unsupported aggregate function %q", fn)
+	}
+	err := r.sc(ctx, scopes...).Select(string(fn)+"(?)", clause.Column{Name: column}).Scan(&out).Error
+	if err != nil {
+		return sql.NullFloat64{}, err
+	}
+	return out, nil
+}
+
+// ExistsInScope checks whether a record with column equal to value exists within baseScope,
+// such as a tenant filter. It is intended for uniqueness checks like "email unique within
+// tenant", where forgetting the scope would yield cross-tenant false results.
+func (r *BaseModel[T]) ExistsInScope(ctx context.Context, baseScope Scope, column string, value any) (_ bool, err error) {
+	defer r.observe(ctx, "ExistsInScope", time.Now(), &err)
+	field := r.schema.LookUpField(column)
+	if field == nil || field.DBName == "" {
+		return false, fmt.Errorf("%w: %s", ErrInvalidColumn, column)
+	}
+	return r.exists(ctx, []Scope{baseScope, Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: value})})
+}
+
+// ResultHash computes a stable hash of the records matching the provided scopes, suitable
+// as an HTTP ETag. For models with an auto-update timestamp (such as UpdatedAt) it is derived
+// from the row count and the latest update time, so it changes whenever a matching record is
+// inserted, updated or deleted through BaseModel, whose updates set that timestamp. Writes
+// that leave it unchanged, such as Exec, RawQuery or raw SQL outside of BaseModel, are not
+// seen unless they set it too. Models without one are hashed from their full contents,
+// which requires reading every matching record.
+func (r *BaseModel[T]) ResultHash(ctx context.Context, scopes ...Scope) (_ string, err error) {
+	defer r.observe(ctx, "ResultHash", time.Now(), &err)
+	h := sha256.New()
+
+	if updatedAt := r.updatedAtField(); updatedAt != nil {
+		var row struct {
+			N int64
+			M sql.NullString
+		}
+		err := r.sc(ctx, scopes...).
+			Select("COUNT(*) AS n, MAX(?) AS m", clause.Column{Table: clause.CurrentTable, Name: updatedAt.DBName}).
+			Scan(&row).Error
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%d|%s", row.N, row.M.String)
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	q := append([]Scope{}, scopes...)
+	if pk := r.schema.PrioritizedPrimaryField; pk != nil {
+		q = append(q, func(db *gorm.DB) *gorm.DB {
+			return db.Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}})
+		})
+	}
+	items, err := r.list(ctx, q)
+	if err != nil {
+		return "", err
+	}
+	enc := json.NewEncoder(h)
+	for i := range items {
+		if err := enc.Encode(items[i]); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FirstForUpdate retrieves the first record that matches the provided scopes
+// with a SELECT FOR UPDATE lock. This method requires a transaction, passed as tx
+// or carried by ctx (see WithTx).
+// Returns ErrNotFound if no record is found, ErrTxRequired if no transaction is provided.
+func (r *BaseModel[T]) FirstForUpdate(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ T, err error) {
+	defer r.observe(ctx, "FirstForUpdate", time.Now(), &err)
+	return r.firstLocked(ctx, tx, clause.Locking{Strength: "UPDATE"}, scopes)
+}
+
+// FindForUpdate retrieves all records that match the provided scopes
+// with a SELECT FOR UPDATE lock. This method requires a transaction, passed as tx
+// or carried by ctx (see WithTx).
+// Returns ErrTxRequired if no transaction is provided.
+func (r *BaseModel[T]) FindForUpdate(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "FindForUpdate", time.Now(), &err)
+	return r.findLocked(ctx, tx, clause.Locking{Strength: "UPDATE"}, scopes)
+}
+
+// FirstForShare retrieves the first record that matches the provided scopes with a
+// SELECT FOR SHARE lock, which blocks writers but not other readers of the row.
+// This method requires a transaction, passed as tx or carried by ctx (see WithTx).
+// Returns ErrNotFound if no record is found, ErrTxRequired if no transaction is provided.
+func (r *BaseModel[T]) FirstForShare(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ T, err error) {
+	defer r.observe(ctx, "FirstForShare", time.Now(), &err)
+	return r.firstLocked(ctx, tx, clause.Locking{Strength: "SHARE"}, scopes)
+}
+
+// FindForShare retrieves all records that match the provided scopes with a
+// SELECT FOR SHARE lock, which blocks writers but not other readers of the rows.
+// This method requires a transaction, passed as tx or carried by ctx (see WithTx).
+// Returns ErrTxRequired if no transaction is provided.
+func (r *BaseModel[T]) FindForShare(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "FindForShare", time.Now(), &err)
+	return r.findLocked(ctx, tx, clause.Locking{Strength: "SHARE"}, scopes)
+}
+
+// FindForUpdateSkipLocked retrieves the records that match the provided scopes with a
+// SELECT FOR UPDATE SKIP LOCKED lock, skipping rows already locked by other transactions.
+// This suits job-queue polling, where workers should not wait on each other's rows.
+// This method requires a transaction, passed as tx or carried by ctx (see WithTx).
+// Returns ErrTxRequired if no transaction is provided and ErrUnsupported on dialects
+// without SKIP LOCKED, such as SQLite.
+func (r *BaseModel[T]) FindForUpdateSkipLocked(ctx context.Context, tx *gorm.DB, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "FindForUpdateSkipLocked", time.Now(), &err)
+	return r.findLocked(ctx, tx, clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}, scopes)
+}
+
+// FirstLocked retrieves the first record that matches the provided scopes with the given
+// row lock, such as clause.Locking{Strength: "UPDATE", Options: "NOWAIT"}.
+// This method requires a transaction, passed as tx or carried by ctx (see WithTx).
+// Returns ErrNotFound if no record is found, ErrTxRequired if no transaction is provided and
+// ErrUnsupported if the dialect does not support the locking options.
+func (r *BaseModel[T]) FirstLocked(ctx context.Context, tx *gorm.DB, locking clause.Locking, scopes ...Scope) (_ T, err error) {
+	defer r.observe(ctx, "FirstLocked", time.Now(), &err)
+	return r.firstLocked(ctx, tx, locking, scopes)
+}
+
+// firstLocked implements FirstLocked.
+func (r *BaseModel[T]) firstLocked(ctx context.Context, tx *gorm.DB, locking clause.Locking, scopes []Scope) (T, error) {
+	var zero T
+	if tx == nil {
+		tx = txFromContext(ctx)
+	}
+	if tx == nil {
+		return zero, ErrTxRequired
+	}
+	if err := checkLockingOptions(tx, locking); err != nil {
+		return zero, err
+	}
+
+	scopes = append(scopes, func(d *gorm.DB) *gorm.DB {
+		return d.Clauses(locking)
+	})
+
+	var v T
+	if err := r.scWithTX(tx, ctx, scopes...).First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return zero, ErrNotFound
+		}
+		return zero, err
+	}
+	if err := r.decrypt(ctx, &v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// FindLocked retrieves all records that match the provided scopes with the given row lock,
+// such as clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}.
+// This method requires a transaction, passed as tx or carried by ctx (see WithTx).
+// Returns ErrTxRequired if no transaction is provided and ErrUnsupported if the dialect
+// does not support the locking options.
+func (r *BaseModel[T]) FindLocked(ctx context.Context, tx *gorm.DB, locking clause.Locking, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "FindLocked", time.Now(), &err)
+	return r.findLocked(ctx, tx, locking, scopes)
+}
+
+// findLocked implements FindLocked.
+func (r *BaseModel[T]) findLocked(ctx context.Context, tx *gorm.DB, locking clause.Locking, scopes []Scope) ([]T, error) {
+	if tx == nil {
+		tx = txFromContext(ctx)
+	}
+	if tx == nil {
+		return nil, ErrTxRequired
+	}
+	if err := checkLockingOptions(tx, locking); err != nil {
+		return nil, err
+	}
+
+	scopes = append(scopes, func(d *gorm.DB) *gorm.DB {
+		return d.Clauses(locking)
+	})
+
+	var out []T
+	if err := r.scWithTX(tx, ctx, scopes...).Find(&out).Error; err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// checkLockingOptions rejects locking options such as SKIP LOCKED and NOWAIT on dialects
+// that would otherwise silently drop them.
+func checkLockingOptions(db *gorm.DB, locking clause.Locking) error {
+	if locking.Options == "" {
+		return nil
+	}
+	switch name := db.Dialector.Name(); name {
+	case "sqlite", "sqlserver":
+		return fmt.Errorf("%w: %s: FOR %s %s", ErrUnsupported, name, locking.Strength, locking.Options)
+	}
+	return nil
+}
+
+// LockByIDsOrdered retrieves the records with the given primary keys using a SELECT FOR UPDATE
+// lock, acquiring row locks in ascending key order. Because every caller locks in the same
+// order regardless of how ids were passed, overlapping lock sets cannot deadlock each other.
+// This method requires a transaction, passed as tx or carried by ctx (see WithTx).
+// Returns ErrTxRequired if no transaction is provided.
+func (r *BaseModel[T]) LockByIDsOrdered(ctx context.Context, tx *gorm.DB, ids []any, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "LockByIDsOrdered", time.Now(), &err)
+	if tx == nil {
+		tx = txFromContext(ctx)
+	}
+	if tx == nil {
+		return nil, ErrTxRequired
+	}
+	pk := r.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return nil, ErrNoPrimaryKey
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	sorted := append([]any(nil), ids...)
+	sort.SliceStable(sorted, func(i, j int) bool { return lessKey(sorted[i], sorted[j]) })
+
+	col := clause.Column{Table: clause.CurrentTable, Name: pk.DBName}
+	scopes = append(scopes, func(d *gorm.DB) *gorm.DB {
+		return d.Where(clause.IN{Column: col, Values: sorted}).
+			Order(clause.OrderByColumn{Column: col}).
+			Clauses(clause.Locking{Strength: "UPDATE"})
+	})
+
+	var out []T
+	if err := r.scWithTX(tx, ctx, scopes...).Find(&out).Error; err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// lessKey orders primary key values of the same kind naturally (numbers numerically,
+// strings lexically) and falls back to comparing their formatted representation.
+func lessKey(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case isInt(va) && isInt(vb):
+		return va.Int() < vb.Int()
+	case isUint(va) && isUint(vb):
+		return va.Uint() < vb.Uint()
+	case va.Kind() == reflect.String && vb.Kind() == reflect.String:
+		return va.String() < vb.String()
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// Page retrieves a paginated result set based on the provided scopes.
+// Page numbers are 1-based. If page <= 0, defaults to 1.
+// If pageSize <= 0, defaults to 20. Maximum pageSize is capped at 1000.
+// Both page size limits can be changed with WithDefaultPageSize and WithMaxPageSize.
+// With Joins, Total counts records (see Count) while Items holds one entry per joined row;
+// add Distinct to get one item per record.
+func (r *BaseModel[T]) Page(ctx context.Context, page, pageSize int, scopes ...Scope) (_ PageResult[T], err error) {
+	defer r.observe(ctx, "Page", time.Now(), &err)
+	page, pageSize = r.normalizePage(page, pageSize)
+
+	// First, get the total count
+	total, err := r.count(ctx, scopes)
+	if err != nil {
+		return PageResult[T]{}, err
+	}
+	totalPages, hasNext, hasPrev := pageInfo(total, page, pageSize)
+
+	// Then, fetch the data for the current page
 	offset := (page - 1) * pageSize
 	var items []T
 	q := append(scopes, Limit(pageSize), Offset(offset))
 	if err := r.sc(ctx, q...).Find(&items).Error; err != nil {
 		return PageResult[T]{}, err
 	}
+	if err := r.decryptAll(ctx, items); err != nil {
+		return PageResult[T]{}, err
+	}
 
 	return PageResult[T]{
-		Items:    items,
-		Total:    total,
-		Page:     page,
-		PageSize: pageSize,
-		HasNext:  int64(page*pageSize) < total,
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
+	}, nil
+}
+
+// PageMeta returns the pagination metadata Page would report for the same inputs,
+// using only a count query. page and pageSize are normalized the same way as in Page.
+func (r *BaseModel[T]) PageMeta(ctx context.Context, page, pageSize int, scopes ...Scope) (total int64, totalPages int, hasNext, hasPrev bool, err error) {
+	defer r.observe(ctx, "PageMeta", time.Now(), &err)
+	page, pageSize = r.normalizePage(page, pageSize)
+
+	total, err = r.count(ctx, scopes)
+	if err != nil {
+		return 0, 0, false, false, err
+	}
+	totalPages, hasNext, hasPrev = pageInfo(total, page, pageSize)
+	return total, totalPages, hasNext, hasPrev, nil
+}
+
+// PageNoCount retrieves one page of records like Page but skips the count query, for feeds
+// and infinite scrolling where the total is not needed. It fetches one row more than
+// pageSize and reports hasNext when that row exists. page and pageSize are normalized the
+// same way as in Page.
+func (r *BaseModel[T]) PageNoCount(ctx context.Context, page, pageSize int, scopes ...Scope) (items []T, hasNext bool, err error) {
+	defer r.observe(ctx, "PageNoCount", time.Now(), &err)
+	page, pageSize = r.normalizePage(page, pageSize)
+
+	q := append(scopes[:len(scopes):len(scopes)], Limit(pageSize+1), Offset((page-1)*pageSize))
+	items, err = r.list(ctx, q)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(items) > pageSize {
+		return items[:pageSize], true, nil
+	}
+	return items, false, nil
+}
+
+// TopPerGroup retrieves, for each group of records sharing the values of partitionBy, the
+// first n records in orderBy order among those matching the provided scopes, such as the
+// three most expensive products per category. It ranks rows with the ROW_NUMBER() window
+// function in a subquery and keeps those ranked n or better, ordered by group and rank.
+// orderBy defaults to the primary key. Columns are validated against the model schema
+// (ErrInvalidColumn). Window functions need PostgreSQL, MySQL 8, or SQLite 3.25 or later.
+// Arbitrary window expressions can be selected with SelectExpr instead.
+func (r *BaseModel[T]) TopPerGroup(ctx context.Context, partitionBy []string, n int, orderBy []OrderSpec, scopes ...Scope) (_ []T, err error) {
+	defer r.observe(ctx, "TopPerGroup", time.Now(), &err)
+	if len(partitionBy) == 0 {
+		return nil, fmt.Errorf("%w: no partition columns", ErrInvalidScope)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: n must be positive", ErrInvalidScope)
+	}
+	column := func(name string) (clause.Column, error) {
+		f := r.schema.LookUpField(name)
+		if f == nil || f.DBName == "" {
+			return clause.Column{}, fmt.Errorf("%w: %s", ErrInvalidColumn, name)
+		}
+		return clause.Column{Table: clause.CurrentTable, Name: f.DBName}, nil
+	}
+
+	var partition []string
+	var vars []any
+	var outerOrder []clause.OrderByColumn
+	for _, name := range partitionBy {
+		col, err := column(name)
+		if err != nil {
+			return nil, err
+		}
+		partition = append(partition, "?")
+		outerOrder = append(outerOrder, clause.OrderByColumn{Column: clause.Column{Name: col.Name}})
+		vars = append(vars, col)
+	}
+	if len(orderBy) == 0 {
+		if pk := r.schema.PrioritizedPrimaryField; pk != nil {
+			orderBy = []OrderSpec{{Column: pk.DBName}}
+		}
+	}
+	var order []string
+	for _, spec := range orderBy {
+		col, err := column(spec.Column)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Desc {
+			order = append(order, "? DESC")
+		} else {
+			order = append(order, "?")
+		}
+		vars = append(vars, col)
+	}
+	over := "PARTITION BY " + strings.Join(partition, ", ")
+	if len(order) > 0 {
+		over += " ORDER BY " + strings.Join(order, ", ")
+	}
+
+	ranked := r.sc(ctx, scopes...).Select(
+		"?.*, ROW_NUMBER() OVER ("+over+") AS gormplus_rank",
+		append([]any{clause.Table{Name: clause.CurrentTable}}, vars...)...,
+	)
+	var out []T
+	err = r.readConn(ctx).WithContext(ctx).Unscoped().
+		Table("(?) AS ranked", ranked).
+		Where("gormplus_rank <= ?", n).
+		Order(clause.OrderBy{Columns: append(outerOrder, clause.OrderByColumn{Column: clause.Column{Name: "gormplus_rank"}})}).
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupedPage aggregates records matching the provided scopes into groups by groupCols,
+// selecting selectExpr (e.g. "status, COUNT(*) AS n, SUM(total) AS revenue") and scanning
+// one page of groups into []R. Groups are sorted by orderBy when it is not empty; Total is the
+// number of groups, after any Having scope. page and pageSize are normalized as in Page.
+// The row type comes first so that T can be inferred from the base model.
+func GroupedPage[R, T any](ctx context.Context, r *BaseModel[T], groupCols []string, selectExpr, orderBy string, page, pageSize int, scopes ...Scope) (_ PageResult[R], err error) {
+	defer r.observe(ctx, "GroupedPage", time.Now(), &err)
+	if len(groupCols) == 0 {
+		return PageResult[R]{}, fmt.Errorf("%w: no group columns", ErrInvalidScope)
+	}
+	page, pageSize = r.normalizePage(page, pageSize)
+	grouped := append(scopes[:len(scopes):len(scopes)], GroupBy(groupCols...))
+
+	var total int64
+	groups := r.sc(ctx, grouped...).Select("1")
+	if err := r.readConn(ctx).WithContext(ctx).Table("(?) AS g", groups).Count(&total).Error; err != nil {
+		return PageResult[R]{}, err
+	}
+
+	q := append(grouped, Select(selectExpr), Limit(pageSize), Offset((page-1)*pageSize))
+	if orderBy != "" {
+		q = append(q, Order(orderBy))
+	}
+	var items []R
+	if err := r.sc(ctx, q...).Scan(&items).Error; err != nil {
+		return PageResult[R]{}, err
+	}
+
+	totalPages, hasNext, hasPrev := pageInfo(total, page, pageSize)
+	return PageResult[R]{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
 	}, nil
 }
 
+// pageInfo derives the page count and navigation flags from a total and normalized page inputs.
+func pageInfo(total int64, page, pageSize int) (totalPages int, hasNext, hasPrev bool) {
+	totalPages = int((total + int64(pageSize) - 1) / int64(pageSize))
+	return totalPages, int64(page*pageSize) < total, page > 1
+}
+
+// normalizePage applies the default page and the configured default and maximum page size
+// (see WithDefaultPageSize and WithMaxPageSize).
+func (r *BaseModel[T]) normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+		if r.opts.pageSize > 0 {
+			pageSize = r.opts.pageSize
+		}
+	}
+	// Cap the page size to prevent excessive resource usage
+	maxPage := 1000
+	if r.opts.maxPage > 0 {
+		maxPage = r.opts.maxPage
+	}
+	if pageSize > maxPage {
+		pageSize = maxPage
+	}
+	return page, pageSize
+}
+
+// batchSizeOf returns the batch size passed as an optional argument, defaulting to 1000.
+func batchSizeOf(batchSize []int) int {
+	if len(batchSize) > 0 && batchSize[0] > 0 {
+		return batchSize[0]
+	}
+	return 1000
+}
+
+// softDeleteField returns the gorm.DeletedAt field of s, or nil if s has no soft delete.
+func softDeleteField(s *schema.Schema) *schema.Field {
+	for _, f := range s.Fields {
+		if f.DBName != "" && f.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+			return f
+		}
+	}
+	return nil
+}
+
+// VerifySchema compares the model's columns with the columns of its database table and
+// returns ErrSchemaDrift listing the columns missing from the table and the extra columns
+// the model does not know about. It is intended for startup checks that catch forgotten
+// migrations early.
+func (r *BaseModel[T]) VerifySchema(ctx context.Context) (err error) {
+	defer r.observe(ctx, "VerifySchema", time.Now(), &err)
+	table := r.TableName()
+	m := r.db.WithContext(ctx).Migrator()
+	if !m.HasTable(table) {
+		return fmt.Errorf("%w: table %s does not exist", ErrSchemaDrift, table)
+	}
+	columns, err := m.ColumnTypes(table)
+	if err != nil {
+		return err
+	}
+
+	actual := make(map[string]struct{}, len(columns))
+	for _, c := range columns {
+		actual[c.Name()] = struct{}{}
+	}
+	var missing, extra []string
+	for _, name := range r.schema.DBNames {
+		if _, ok := actual[name]; !ok {
+			missing = append(missing, name)
+		}
+		delete(actual, name)
+	}
+	for name := range actual {
+		extra = append(extra, name)
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+
+	sort.Strings(extra)
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing columns: "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		problems = append(problems, "extra columns: "+strings.Join(extra, ", "))
+	}
+	return fmt.Errorf("%w: table %s: %s", ErrSchemaDrift, table, strings.Join(problems, "; "))
+}
+
+// supportsReturning reports whether a callback processor registered the RETURNING clause,
+// which GORM dialectors only do when the underlying database supports it.
+func supportsReturning(clauses []string) bool {
+	for _, c := range clauses {
+		if c == "RETURNING" {
+			return true
+		}
+	}
+	return false
+}
+
+// PageCursor retrieves a page of records using keyset pagination on cursorColumn, which
+// stays fast on deep pages where Page's OFFSET degrades. The query becomes
+// WHERE cursorColumn > after ORDER BY cursorColumn LIMIT limit+1; a nil after starts from
+// the beginning. cursorColumn should be unique (typically the primary key) so no rows are
+// skipped between pages.
+// The returned nextCursor is the cursor value of the last item, or nil when there are no
+// further records. limit is normalized like Page's pageSize: by default, a limit <= 0 means
+// 20 and limits are capped at 1000.
+func (r *BaseModel[T]) PageCursor(ctx context.Context, cursorColumn string, after any, limit int, scopes ...Scope) (_ []T, _ any, err error) {
+	defer r.observe(ctx, "PageCursor", time.Now(), &err)
+	field := r.schema.LookUpField(cursorColumn)
+	if field == nil || field.DBName == "" {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidColumn, cursorColumn)
+	}
+	_, limit = r.normalizePage(1, limit)
+
+	col := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+	q := append([]Scope{}, scopes...)
+	if after != nil {
+		q = append(q, Where(clause.Gt{Column: col, Value: after}))
+	}
+	q = append(q, func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{Column: col})
+	}, Limit(limit+1))
+
+	var items []T
+	if err := r.sc(ctx, q...).Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+	if err := r.decryptAll(ctx, items); err != nil {
+		return nil, nil, err
+	}
+	if len(items) <= limit {
+		return items, nil, nil
+	}
+
+	items = items[:limit]
+	next, _ := field.ValueOf(ctx, reflect.ValueOf(&items[limit-1]).Elem())
+	return items, next, nil
+}
+
+// ValidateScopes builds the query that List would run with the provided scopes in a
+// dry-run session and returns any error raised while constructing it, such as a scope
+// rejecting its arguments. The database is not queried, so errors only the database can
+// detect (like unknown columns in raw SQL fragments) are not reported.
+func (r *BaseModel[T]) ValidateScopes(scopes ...Scope) error {
+	dry := r.db.Session(&gorm.Session{DryRun: true})
+	var out []T
+	return r.scWithTX(dry, context.Background(), scopes...).Find(&out).Error
+}
+
+// ToSQL renders the SELECT that List would run with the provided scopes, with arguments
+// interpolated, for debugging. The query is built in a dry-run session and never reaches
+// the database. Errors raised by scopes are not reported; use ValidateScopes for those.
+func (r *BaseModel[T]) ToSQL(scopes ...Scope) string {
+	return r.db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var out []T
+		return r.scWithTX(tx, context.Background(), scopes...).Find(&out)
+	})
+}
+
+// encrypt replaces the plaintext of encrypted columns in ent with ciphertext.
+// The returned function restores the plaintext and must be called once the write is done,
+// so callers never observe ciphertext in their entities.
+func (r *BaseModel[T]) encrypt(ctx context.Context, ent *T) (func(), error) {
+	if len(r.opts.encrypted) == 0 {
+		return func() {}, nil
+	}
+
+	rv := reflect.ValueOf(ent).Elem()
+	var restores []func()
+	restore := func() {
+		for _, fn := range restores {
+			fn()
+		}
+	}
+	for _, c := range r.opts.encrypted {
+		fv := c.field.ReflectValueOf(ctx, rv)
+		if fv.Len() == 0 {
+			continue
+		}
+		orig := reflect.New(fv.Type()).Elem()
+		orig.Set(fv)
+
+		var plain []byte
+		if fv.Kind() == reflect.String {
+			plain = []byte(fv.String())
+		} else {
+			plain = fv.Bytes()
+		}
+		cipher, err := c.enc(plain)
+		if err != nil {
+			restore()
+			return nil, fmt.Errorf("encrypt %s: %w", c.column, err)
+		}
+		if fv.Kind() == reflect.String {
+			fv.SetString(base64.StdEncoding.EncodeToString(cipher))
+		} else {
+			fv.SetBytes(cipher)
+		}
+		restores = append(restores, func() { fv.Set(orig) })
+	}
+	return restore, nil
+}
+
+// decrypt replaces the ciphertext of encrypted columns in ent with plaintext.
+func (r *BaseModel[T]) decrypt(ctx context.Context, ent *T) error {
+	if len(r.opts.encrypted) == 0 {
+		return nil
+	}
+
+	rv := reflect.ValueOf(ent).Elem()
+	for _, c := range r.opts.encrypted {
+		fv := c.field.ReflectValueOf(ctx, rv)
+		if fv.Len() == 0 {
+			continue
+		}
+
+		var cipher []byte
+		if fv.Kind() == reflect.String {
+			b, err := base64.StdEncoding.DecodeString(fv.String())
+			if err != nil {
+				return fmt.Errorf("decrypt %s: %w", c.column, err)
+			}
+			cipher = b
+		} else {
+			cipher = fv.Bytes()
+		}
+		plain, err := c.dec(cipher)
+		if err != nil {
+			return fmt.Errorf("decrypt %s: %w", c.column, err)
+		}
+		if fv.Kind() == reflect.String {
+			fv.SetString(string(plain))
+		} else {
+			fv.SetBytes(plain)
+		}
+	}
+	return nil
+}
+
+// decryptAll decrypts every entity in ents in place.
+func (r *BaseModel[T]) decryptAll(ctx context.Context, ents []T) error {
+	if len(r.opts.encrypted) == 0 {
+		return nil
+	}
+	for i := range ents {
+		if err := r.decrypt(ctx, &ents[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withTable points db at the WithTableName override, if any.
+func (r *BaseModel[T]) withTable(db *gorm.DB) *gorm.DB {
+	if r.opts.table != "" {
+		return db.Table(r.opts.table)
+	}
+	return db
+}
+
+// conn returns the connection to run on: tx if provided, otherwise the transaction carried
+// by ctx, otherwise the base model's default DB.
+func (r *BaseModel[T]) conn(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	if tx := txFromContext(ctx); tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+// readConn returns the connection for a read: the transaction carried by ctx, if any,
+// otherwise the next replica unless ctx pins reads to the primary, otherwise the base
+// model's default DB.
+func (r *BaseModel[T]) readConn(ctx context.Context) *gorm.DB {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx
+	}
+	if n := uint64(len(r.opts.replicas)); n > 0 && ctx.Value(primaryKey{}) == nil {
+		return r.opts.replicas[(r.nextReplica.Add(1)-1)%n]
+	}
+	return r.db
+}
+
+// inTx runs fn in tx if provided, otherwise in the transaction carried by ctx,
+// otherwise in a new transaction.
+func (r *BaseModel[T]) inTx(ctx context.Context, tx *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if tx == nil {
+		tx = txFromContext(ctx)
+	}
+	if tx != nil {
+		return fn(tx)
+	}
+	return r.db.WithContext(ctx).Transaction(fn)
+}
+
 // sc creates a base query with context and model, then applies the provided scopes.
-// This is the unified starting point for all query operations.
+// This is the unified starting point for all query operations; it runs on a replica
+// when the base model has any (see readConn).
 func (r *BaseModel[T]) sc(ctx context.Context, scopes ...Scope) *gorm.DB {
-	db := r.db.WithContext(ctx).Model(new(T))
+	db := r.withTable(r.readConn(ctx).WithContext(ctx).Model(new(T)))
 	for _, s := range scopes {
 		if s != nil {
 			db = s(db)
@@ -330,12 +4018,13 @@ func (r *BaseModel[T]) sc(ctx context.Context, scopes ...Scope) *gorm.DB {
 }
 
 // scWithTX creates a base query with context and model using the provided transaction,
-// then applies the provided scopes. If db is nil, falls back to the base model's default DB.
+// then applies the provided scopes. If db is nil, falls back to the transaction carried by ctx,
+// if any, and then to the base model's default DB.
 func (r *BaseModel[T]) scWithTX(db *gorm.DB, ctx context.Context, scopes ...Scope) *gorm.DB {
 	if db == nil {
-		db = r.db
+		db = r.conn(ctx, nil)
 	}
-	q := db.WithContext(ctx).Model(new(T))
+	q := r.withTable(db.WithContext(ctx).Model(new(T)))
 	for _, s := range scopes {
 		if s != nil {
 			q = s(q)
@@ -0,0 +1,60 @@
+//go:build go1.23
+
+package gormplus
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// Iterate streams the records matching the provided scopes one at a time, without buffering
+// the result set:
+//
+//	for user, err := range userBaseModel.Iterate(ctx, gormplus.Where("active = ?", true)) {
+//		if err != nil {
+//			return err
+//		}
+//		// process user
+//	}
+//
+// The underlying rows are closed when iteration finishes or the loop exits early.
+// Errors, including the context error once ctx is cancelled, are yielded with a zero T
+// and end the stream. Requires Go 1.23 or later.
+func (r *BaseModel[T]) Iterate(ctx context.Context, scopes ...Scope) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var err error
+		defer r.observe(ctx, "Iterate", time.Now(), &err)
+
+		var zero T
+		rows, err := r.sc(ctx, scopes...).Rows()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+
+		scanner := r.conn(ctx, nil).WithContext(ctx)
+		for rows.Next() {
+			if err = ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			var v T
+			if err = scanner.ScanRows(rows, &v); err != nil {
+				yield(zero, err)
+				return
+			}
+			if err = r.decrypt(ctx, &v); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err = rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
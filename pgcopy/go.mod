@@ -0,0 +1,20 @@
+module github.com/nullcache/gorm-plus/pgcopy
+
+go 1.19
+
+require (
+	github.com/jackc/pgx/v5 v5.5.0
+	github.com/nullcache/gorm-plus v0.1.0
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/crypto v0.9.0 // indirect
+	golang.org/x/text v0.20.0 // indirect
+	gorm.io/gorm v1.30.5 // indirect
+)
+
+replace github.com/nullcache/gorm-plus => ../
@@ -0,0 +1,316 @@
+//go:build postgres
+
+package gormplus_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	gormplus "github.com/nullcache/gorm-plus"
+	"github.com/nullcache/gorm-plus/pgcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// ============================================================================
+// PostgreSQL Setup
+// ============================================================================
+
+// setupPostgresDB connects to the database named by GORM_PLUS_POSTGRES_DSN and
+// recreates the test tables. Run with: go test -tags postgres ./...
+func setupPostgresDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("GORM_PLUS_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("GORM_PLUS_POSTGRES_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.Migrator().DropTable(&Order{}, &User{}, &Product{})
+	require.NoError(t, err)
+	err = db.AutoMigrate(&User{}, &Order{}, &Product{})
+	require.NoError(t, err)
+
+	return db
+}
+
+// ============================================================================
+// PostgreSQL Bulk Load Tests
+// ============================================================================
+
+func TestPostgres_CopyInsert(t *testing.T) {
+	db := setupPostgresDB(t)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	users := make([]*User, 10000)
+	for i := range users {
+		users[i] = &User{
+			Name:  fmt.Sprintf("User%d", i),
+			Email: fmt.Sprintf("user%d@example.com", i),
+			Age:   i % 100,
+		}
+	}
+
+	n, err := pgcopy.CopyInsert(ctx, baseModel, users)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), n)
+
+	count, err := baseModel.Count(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), count)
+
+	found, err := baseModel.First(ctx, gormplus.Where("email = ?", "user4242@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, "User4242", found.Name)
+	assert.Equal(t, 42, found.Age)
+	assert.NotZero(t, found.CreatedAt)
+}
+
+func TestPostgres_CopyInsert_InTx(t *testing.T) {
+	db := setupPostgresDB(t)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	users := []*User{
+		{Name: "User1", Email: "user1@example.com"},
+		{Name: "User2", Email: "user2@example.com"},
+	}
+
+	// The load joins the transaction carried by ctx and is rolled back with it
+	tx := db.Begin()
+	require.NoError(t, tx.Error)
+	n, err := pgcopy.CopyInsert(gormplus.WithTx(ctx, tx), baseModel, users)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	require.NoError(t, tx.Rollback().Error)
+
+	count, err := baseModel.Count(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+// Shipment has defaults COPY must not overwrite with zero values.
+type Shipment struct {
+	ID        uint      `gorm:"primaryKey"`
+	Status    string    `gorm:"default:'pending'"`
+	ArrivedAt time.Time `gorm:"default:now()"`
+	CreatedAt time.Time
+}
+
+func TestPostgres_CopyInsert_Defaults(t *testing.T) {
+	db := setupPostgresDB(t)
+	require.NoError(t, db.Migrator().DropTable(&Shipment{}))
+	require.NoError(t, db.AutoMigrate(&Shipment{}))
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	db.Config.NowFunc = func() time.Time { return fixed }
+	baseModel, err := gormplus.NewBaseModel[Shipment](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	n, err := pgcopy.CopyInsert(ctx, baseModel, []*Shipment{{}, {Status: "sent"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	found, err := baseModel.List(ctx, gormplus.Order("id"))
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "pending", found[0].Status)
+	assert.Equal(t, "sent", found[1].Status)
+	assert.True(t, fixed.Equal(found[0].CreatedAt))
+	// Zero in every entity, so the database default applies
+	assert.False(t, found[0].ArrivedAt.IsZero())
+}
+
+func TestPostgres_BatchUpdate(t *testing.T) {
+	db := setupPostgresDB(t)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	users := make([]*User, 5)
+	for i := range users {
+		users[i] = &User{Name: fmt.Sprintf("User%d", i), Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+	require.NoError(t, baseModel.BatchInsert(ctx, nil, users))
+
+	for i, u := range users {
+		u.Age = 30 + i
+		u.Name = fmt.Sprintf("Renamed%d", i)
+	}
+	err = baseModel.BatchUpdate(ctx, nil, users, []string{"age", "name"}, 2)
+	require.NoError(t, err)
+
+	found, err := baseModel.List(ctx, gormplus.Order("id"))
+	require.NoError(t, err)
+	require.Len(t, found, 5)
+	for i, u := range found {
+		assert.Equal(t, 30+i, u.Age)
+		assert.Equal(t, fmt.Sprintf("Renamed%d", i), u.Name)
+	}
+}
+
+// ============================================================================
+// PostgreSQL Transaction Tests
+// ============================================================================
+
+func TestPostgres_TransactWithOptions_ReadOnly(t *testing.T) {
+	db := setupPostgresDB(t)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = baseModel.TransactWithOptions(ctx, &sql.TxOptions{ReadOnly: true}, func(ctx context.Context, tx *gorm.DB) error {
+		return baseModel.Create(ctx, tx, &User{Name: "User1", Email: "user1@example.com"})
+	})
+	assert.Error(t, err)
+
+	count, err := baseModel.Count(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestPostgres_ForShare(t *testing.T) {
+	db := setupPostgresDB(t)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user := &User{Name: "User1", Email: "user1@example.com"}
+	require.NoError(t, baseModel.Create(ctx, nil, user))
+	byID := gormplus.Where("id = ?", user.ID)
+
+	holder := db.Begin()
+	require.NoError(t, holder.Error)
+	defer holder.Rollback()
+	_, err = baseModel.FirstForShare(ctx, holder, byID)
+	require.NoError(t, err)
+
+	// Other readers may share the lock
+	err = db.Transaction(func(tx *gorm.DB) error {
+		_, err := baseModel.FindForShare(ctx, tx, byID)
+		return err
+	})
+	assert.NoError(t, err)
+
+	// Writers are blocked
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL lock_timeout = '100ms'").Error; err != nil {
+			return err
+		}
+		return baseModel.UpdateColumn(ctx, tx, "age", 1, byID)
+	})
+	assert.Error(t, err)
+}
+
+func TestPostgres_LockingOptions(t *testing.T) {
+	db := setupPostgresDB(t)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	users := []*User{
+		{Name: "Job1", Email: "job1@example.com"},
+		{Name: "Job2", Email: "job2@example.com"},
+	}
+	require.NoError(t, baseModel.BatchInsert(ctx, nil, users))
+
+	worker := db.Begin()
+	require.NoError(t, worker.Error)
+	defer worker.Rollback()
+	claimed, err := baseModel.FindForUpdateSkipLocked(ctx, worker, gormplus.Order("id"), gormplus.Limit(1))
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, users[0].ID, claimed[0].ID)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// A second worker skips the claimed row
+		next, err := baseModel.FindForUpdateSkipLocked(ctx, tx, gormplus.Order("id"), gormplus.Limit(1))
+		if err != nil {
+			return err
+		}
+		assert.Len(t, next, 1)
+		assert.Equal(t, users[1].ID, next[0].ID)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// NOWAIT fails instead of blocking on the claimed row
+	err = db.Transaction(func(tx *gorm.DB) error {
+		_, err := baseModel.FirstLocked(ctx, tx, clause.Locking{Strength: "UPDATE", Options: "NOWAIT"},
+			gormplus.Where("id = ?", users[0].ID))
+		return err
+	})
+	assert.Error(t, err)
+}
+
+func TestPostgres_TransactWithLockTimeout(t *testing.T) {
+	db := setupPostgresDB(t)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user := &User{Name: "Locked", Email: "locked@example.com"}
+	require.NoError(t, baseModel.Create(ctx, nil, user))
+
+	holder := db.Begin()
+	require.NoError(t, holder.Error)
+	defer holder.Rollback()
+	_, err = baseModel.FirstForUpdate(ctx, holder, gormplus.Where("id = ?", user.ID))
+	require.NoError(t, err)
+
+	// The waiting transaction gives up after the timeout instead of blocking
+	start := time.Now()
+	err = baseModel.TransactWithLockTimeout(ctx, 100*time.Millisecond, func(ctx context.Context, tx *gorm.DB) error {
+		_, err := baseModel.FirstForUpdate(ctx, tx, gormplus.Where("id = ?", user.ID))
+		return err
+	})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+// ============================================================================
+// PostgreSQL Scope Tests
+// ============================================================================
+
+func TestPostgres_WhereArrayContains(t *testing.T) {
+	db := setupPostgresDB(t)
+	require.NoError(t, db.Exec("ALTER TABLE users ADD COLUMN tags text[]").Error)
+	baseModel, err := gormplus.NewBaseModel[User](db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	users := []*User{
+		{Name: "Gopher", Email: "gopher@example.com"},
+		{Name: "Dba", Email: "dba@example.com"},
+	}
+	require.NoError(t, baseModel.BatchInsert(ctx, nil, users))
+	require.NoError(t, db.Exec("UPDATE users SET tags = ARRAY['go','sql'] WHERE id = ?", users[0].ID).Error)
+	require.NoError(t, db.Exec("UPDATE users SET tags = ARRAY['sql'] WHERE id = ?", users[1].ID).Error)
+
+	found, err := baseModel.List(ctx, gormplus.WhereArrayContains("tags", []string{"sql"}), gormplus.Order("id"))
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	found, err = baseModel.List(ctx, gormplus.WhereArrayContains("tags", []string{"go", "sql"}))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Gopher", found[0].Name)
+
+	_, err = baseModel.List(ctx, gormplus.WhereArrayContains("tags", "go"))
+	assert.ErrorIs(t, err, gormplus.ErrInvalidScope)
+}